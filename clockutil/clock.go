@@ -0,0 +1,77 @@
+// Package clockutil 提供可注入的时钟抽象，便于编写确定性的时间相关测试。
+package clockutil
+
+import (
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// Clock 时钟接口；生产代码使用 Real，测试使用 NewFake 创建的 FakeClock。
+type Clock interface {
+	// Now 返回当前时刻。
+	Now() time.Time
+	// Since 返回自 t 起经过的时长。
+	Since(t time.Time) time.Duration
+	// After 在 d 之后向返回的通道发送当时时刻。
+	After(d time.Duration) <-chan time.Time
+	// NewTimer 创建在 d 之后触发的定时器。
+	NewTimer(d time.Duration) Timer
+	// AfterFunc 在 d 之后于独立 goroutine 中执行 f。
+	AfterFunc(d time.Duration, f func()) Timer
+	// Sleep 阻塞 d。
+	Sleep(d time.Duration)
+}
+
+// Timer 定时器接口，语义同 time.Timer。
+type Timer interface {
+	// C 返回触发时接收时刻的通道；AfterFunc 创建的定时器返回 nil。
+	C() <-chan time.Time
+	// Stop 停止定时器，若定时器尚未触发返回 true。
+	Stop() bool
+	// Reset 将定时器改为 d 后触发，若定时器此前尚未触发返回 true。
+	Reset(d time.Duration) bool
+}
+
+// Real 基于系统时间的时钟。
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r realTimer) C() <-chan time.Time        { return r.t.C }
+func (r realTimer) Stop() bool                 { return r.t.Stop() }
+func (r realTimer) Reset(d time.Duration) bool { return r.t.Reset(d) }
+
+// NowDate 返回时钟 c 当前时刻在 time.Local 中的日期；c 为 nil 时使用 Real。
+func NowDate(c Clock) types.LocalDate {
+	return types.LocalDateOfLoc(orReal(c).Now())
+}
+
+// NowDateTime 返回时钟 c 当前时刻在 time.Local 中的日期时间；c 为 nil 时使用 Real。
+func NowDateTime(c Clock) types.LocalDateTime {
+	return types.LocalDateTimeOfLoc(orReal(c).Now())
+}
+
+func orReal(c Clock) Clock {
+	if c == nil {
+		return Real
+	}
+	return c
+}
@@ -0,0 +1,94 @@
+package clockutil
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReal(t *testing.T) {
+	as := assert.New(t)
+	before := time.Now()
+	as.False(Real.Now().Before(before))
+	as.False(NowDate(nil).IsZero())
+
+	timer := Real.NewTimer(time.Millisecond)
+	<-timer.C()
+	as.False(timer.Stop())
+}
+
+func TestFakeClock(t *testing.T) {
+	as := assert.New(t)
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+	as.Equal(start, c.Now())
+
+	c.Advance(90 * time.Second)
+	as.Equal(start.Add(90*time.Second), c.Now())
+	as.Equal(90*time.Second, c.Since(start))
+
+	c.Set(start)
+	as.Equal(start, c.Now(), "可回拨")
+	as.Equal(types.LocalDateTimeOfLoc(start), NowDateTime(c))
+	as.Equal(types.LocalDateOfLoc(start), NowDate(c))
+}
+
+func TestFakeClock_timers(t *testing.T) {
+	as := assert.New(t)
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+
+	t1 := c.NewTimer(time.Minute)
+	after := c.After(2 * time.Minute)
+	var fired atomic.Int32
+	done := make(chan struct{})
+	c.AfterFunc(30*time.Second, func() {
+		fired.Add(1)
+		close(done)
+	})
+	as.Equal(3, c.PendingTimers())
+
+	c.Advance(59 * time.Second)
+	<-done
+	as.Equal(int32(1), fired.Load())
+	select {
+	case <-t1.C():
+		t.Fatal("定时器提前触发")
+	default:
+	}
+
+	c.Advance(time.Second)
+	as.Equal(start.Add(time.Minute), <-t1.C())
+	as.False(t1.Stop(), "已触发")
+
+	as.False(t1.Reset(time.Hour), "已触发的定时器 Reset 返回 false")
+	as.True(t1.Stop())
+	c.Advance(2 * time.Hour)
+	select {
+	case <-t1.C():
+		t.Fatal("已停止的定时器不应触发")
+	default:
+	}
+	as.Equal(start.Add(2*time.Minute), <-after, "跨越多个到期点时按到期时刻发送")
+	as.Equal(0, c.PendingTimers())
+
+	immediate := c.NewTimer(0)
+	as.Equal(c.Now(), <-immediate.C())
+}
+
+func TestFakeClock_Sleep(t *testing.T) {
+	c := NewFake(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	woke := make(chan struct{})
+	go func() {
+		c.Sleep(time.Hour)
+		close(woke)
+	}()
+	for c.PendingTimers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Advance(time.Hour)
+	<-woke
+}
@@ -0,0 +1,158 @@
+package clockutil
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// FakeClock 手动推进的时钟，并发安全。
+// 时间只会在 Advance / Set 时变化，此时到期的定时器按到期先后依次触发。
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake 创建当前时刻为 now 的假时钟。
+func NewFake(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now 返回假时钟的当前时刻。
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since 返回自 t 起到假时钟当前时刻的时长。
+func (f *FakeClock) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After 返回在假时钟推进 d 后收到时刻的通道。
+func (f *FakeClock) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer 创建在假时钟推进 d 后触发的定时器；d <= 0 时立即触发。
+func (f *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: f, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc 创建在假时钟推进 d 后于独立 goroutine 中执行 fn 的定时器。
+func (f *FakeClock) AfterFunc(d time.Duration, fn func()) Timer {
+	t := &fakeTimer{clock: f, fn: fn}
+	t.Reset(d)
+	return t
+}
+
+// Sleep 阻塞直到其他 goroutine 将假时钟推进 d；d <= 0 时立即返回。
+func (f *FakeClock) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// Advance 将假时钟推进 d，并触发期间到期的定时器。
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	target := f.now.Add(d)
+	f.mu.Unlock()
+	f.Set(target)
+}
+
+// Set 将假时钟设为 t，并触发到期的定时器；t 早于当前时刻时仅回拨时间。
+func (f *FakeClock) Set(t time.Time) {
+	for {
+		f.mu.Lock()
+		next := f.nextDue(t)
+		if next == nil {
+			f.now = t
+			f.mu.Unlock()
+			return
+		}
+		// 先推进到定时器到期时刻，使回调内读到的 Now 与到期时刻一致
+		if next.deadline.After(f.now) {
+			f.now = next.deadline
+		}
+		f.removeLocked(next)
+		now := f.now
+		f.mu.Unlock()
+		next.fire(now)
+	}
+}
+
+// PendingTimers 返回尚未触发的定时器数量，便于测试等待被测代码注册定时器。
+func (f *FakeClock) PendingTimers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// nextDue 返回截至 t 最早到期的定时器。
+func (f *FakeClock) nextDue(t time.Time) *fakeTimer {
+	var next *fakeTimer
+	for _, ft := range f.timers {
+		if !ft.deadline.After(t) && (next == nil || ft.deadline.Before(next.deadline)) {
+			next = ft
+		}
+	}
+	return next
+}
+
+// removeLocked 移除定时器，返回其是否仍在等待触发。
+func (f *FakeClock) removeLocked(t *fakeTimer) bool {
+	i := slices.Index(f.timers, t)
+	if i < 0 {
+		return false
+	}
+	f.timers = slices.Delete(f.timers, i, i+1)
+	return true
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+	fn       func()
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.removeLocked(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	f := t.clock
+	f.mu.Lock()
+	active := f.removeLocked(t)
+	t.deadline = f.now.Add(d)
+	if d > 0 {
+		f.timers = append(f.timers, t)
+		f.mu.Unlock()
+		return active
+	}
+	now := f.now
+	f.mu.Unlock()
+	t.fire(now)
+	return active
+}
+
+func (t *fakeTimer) fire(now time.Time) {
+	if t.fn != nil {
+		go t.fn()
+		return
+	}
+	// 与 time.Timer 一致：通道已满时丢弃
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
@@ -0,0 +1,309 @@
+package codeutil
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrChecksumMismatch 表示 Crockford Base32 校验符与内容不一致。
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// Encoder 将字节或整数编码为指定字母表的字符串。
+type Encoder interface {
+	EncodeBytes(data []byte) string
+	DecodeBytes(s string) ([]byte, error)
+	EncodeUint64(n uint64) string
+	DecodeUint64(s string) (uint64, error)
+}
+
+var (
+	// Base58Encoder 比特币风格 Base58，前导零字节编码为前导 '1'。
+	Base58Encoder = mustBaseNEncoder(Base58Charset)
+	// Base62Encoder Base62（短链、邀请码）。
+	Base62Encoder = mustBaseNEncoder(Base62Charset)
+	// Base36Encoder Base36（数字 + 小写字母），解码时大小写不敏感。
+	Base36Encoder = mustBaseNEncoder(Base36Charset)
+	// CrockfordBase32Encoder Crockford Base32，不带校验符。
+	CrockfordBase32Encoder = NewCrockfordEncoder(false)
+	// CrockfordBase32CheckEncoder Crockford Base32，末尾附加一位校验符。
+	CrockfordBase32CheckEncoder = NewCrockfordEncoder(true)
+)
+
+// BaseNEncoder 按大整数进制转换编码的通用 Base-N 编码器（Base58/Base62/Base36 等）。
+// 字节编码时每个前导零字节对应一个字母表首字符，以保证往返一致。
+type BaseNEncoder struct {
+	alphabet string
+	base     *big.Int
+	index    [256]int
+}
+
+// NewBaseNEncoder 由字母表创建编码器；字母表须为 ASCII、不重复且至少 2 个字符，否则返回错误。
+// 字母表中的字母只有一种大小写时（如 Base36），解码时大小写不敏感。
+func NewBaseNEncoder(alphabet string) (*BaseNEncoder, error) {
+	if len(alphabet) < 2 {
+		return nil, fmt.Errorf("字母表至少需要 2 个字符")
+	}
+	e := &BaseNEncoder{alphabet: alphabet, base: big.NewInt(int64(len(alphabet)))}
+	for i := range e.index {
+		e.index[i] = -1
+	}
+	hasLower, hasUpper := false, false
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			return nil, fmt.Errorf("字母表仅支持 ASCII 字符")
+		}
+		if e.index[c] >= 0 {
+			return nil, fmt.Errorf("字母表包含重复字符: %q", c)
+		}
+		e.index[c] = i
+		hasLower = hasLower || c >= 'a' && c <= 'z'
+		hasUpper = hasUpper || c >= 'A' && c <= 'Z'
+	}
+	if hasLower != hasUpper {
+		for c := 'a'; c <= 'z'; c++ {
+			if hasLower {
+				e.index[c-'a'+'A'] = e.index[c]
+			} else {
+				e.index[c] = e.index[c-'a'+'A']
+			}
+		}
+	}
+	return e, nil
+}
+
+// mustBaseNEncoder 同 NewBaseNEncoder，出错时 panic，用于内置字母表。
+func mustBaseNEncoder(alphabet string) *BaseNEncoder {
+	e, err := NewBaseNEncoder(alphabet)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// EncodeBytes 编码字节切片；nil 或空切片返回空串。
+func (e *BaseNEncoder) EncodeBytes(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	num := new(big.Int).SetBytes(data[zeros:])
+	mod := new(big.Int)
+	var digits []byte
+	for num.Sign() > 0 {
+		num.DivMod(num, e.base, mod)
+		digits = append(digits, e.alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, e.alphabet[0])
+	}
+	reverseBytes(digits)
+	return string(digits)
+}
+
+// DecodeBytes 解码 EncodeBytes 的结果；含字母表外字符时返回错误。
+func (e *BaseNEncoder) DecodeBytes(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && e.index[s[zeros]] == 0 {
+		zeros++
+	}
+
+	num := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		d := e.index[s[i]]
+		if d < 0 {
+			return nil, fmt.Errorf("非法字符 %q（位置 %d）", s[i], i)
+		}
+		num.Mul(num, e.base)
+		num.Add(num, big.NewInt(int64(d)))
+	}
+
+	out := make([]byte, zeros, zeros+len(s))
+	return append(out, num.Bytes()...), nil
+}
+
+// EncodeUint64 编码整数，0 编码为字母表首字符。
+func (e *BaseNEncoder) EncodeUint64(n uint64) string {
+	return string(encodeRadix(n, e.alphabet))
+}
+
+// DecodeUint64 解码整数；空串、非法字符或溢出时返回错误。
+func (e *BaseNEncoder) DecodeUint64(s string) (uint64, error) {
+	return decodeRadix(s, uint64(len(e.alphabet)), func(c byte) int { return e.index[c] })
+}
+
+// crockfordCheckSymbols 校验值 32~36 对应的额外符号。
+const crockfordCheckSymbols = "*~$=U"
+
+// CrockfordEncoder Crockford Base32 编码器。
+// 字节按 5 bit 分组编码（无填充）；解码时大小写不敏感，I/L 视为 1、O 视为 0，忽略连字符。
+// 启用校验时末尾附加一位 mod 37 校验符。
+type CrockfordEncoder struct {
+	checksum bool
+}
+
+// NewCrockfordEncoder 创建 Crockford Base32 编码器，checksum 表示是否附加校验符。
+func NewCrockfordEncoder(checksum bool) *CrockfordEncoder {
+	return &CrockfordEncoder{checksum: checksum}
+}
+
+// EncodeBytes 编码字节切片；nil 或空切片返回空串（启用校验时仅含校验符）。
+func (e *CrockfordEncoder) EncodeBytes(data []byte) string {
+	out := make([]byte, 0, (len(data)*8+4)/5+1)
+	var buf uint
+	bits := 0
+	mod := 0
+	for _, b := range data {
+		buf = buf<<8 | uint(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out = append(out, CrockfordBase32Charset[(buf>>uint(bits))&31])
+		}
+		mod = (mod*256 + int(b)) % 37
+	}
+	if bits > 0 {
+		out = append(out, CrockfordBase32Charset[(buf<<uint(5-bits))&31])
+	}
+	if e.checksum {
+		out = append(out, crockfordCheckSymbol(mod))
+	}
+	return string(out)
+}
+
+// DecodeBytes 解码 EncodeBytes 的结果。
+func (e *CrockfordEncoder) DecodeBytes(s string) ([]byte, error) {
+	body, check, err := e.split(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(body)*5%8 >= 5 {
+		return nil, fmt.Errorf("Crockford Base32 长度非法: %d", len(body))
+	}
+
+	out := make([]byte, 0, len(body)*5/8)
+	var buf uint
+	bits := 0
+	mod := 0
+	for i := 0; i < len(body); i++ {
+		d := crockfordValue(body[i])
+		if d < 0 {
+			return nil, fmt.Errorf("非法字符 %q（位置 %d）", body[i], i)
+		}
+		buf = buf<<5 | uint(d)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			b := byte(buf >> uint(bits))
+			out = append(out, b)
+			mod = (mod*256 + int(b)) % 37
+		}
+	}
+	if bits > 0 && buf&(1<<uint(bits)-1) != 0 {
+		return nil, fmt.Errorf("Crockford Base32 末尾填充位非零")
+	}
+	if e.checksum && crockfordCheckSymbol(mod) != check {
+		return nil, ErrChecksumMismatch
+	}
+	return out, nil
+}
+
+// EncodeUint64 编码整数（32 进制），启用校验时附加 n mod 37 校验符。
+func (e *CrockfordEncoder) EncodeUint64(n uint64) string {
+	out := encodeRadix(n, CrockfordBase32Charset)
+	if e.checksum {
+		out = append(out, crockfordCheckSymbol(int(n%37)))
+	}
+	return string(out)
+}
+
+// DecodeUint64 解码整数；空串、非法字符、溢出或校验失败时返回错误。
+func (e *CrockfordEncoder) DecodeUint64(s string) (uint64, error) {
+	body, check, err := e.split(s)
+	if err != nil {
+		return 0, err
+	}
+	n, err := decodeRadix(body, 32, crockfordValue)
+	if err != nil {
+		return 0, err
+	}
+	if e.checksum && crockfordCheckSymbol(int(n%37)) != check {
+		return 0, ErrChecksumMismatch
+	}
+	return n, nil
+}
+
+// split 去除连字符，并在启用校验时拆出末位校验符。
+func (e *CrockfordEncoder) split(s string) (string, byte, error) {
+	s = strings.ReplaceAll(s, "-", "")
+	if !e.checksum {
+		return s, 0, nil
+	}
+	if s == "" {
+		return "", 0, fmt.Errorf("缺少校验符")
+	}
+	last := s[len(s)-1]
+	if last >= 'a' && last <= 'z' {
+		last -= 'a' - 'A'
+	}
+	return s[:len(s)-1], last, nil
+}
+
+func crockfordCheckSymbol(mod int) byte {
+	if mod < 32 {
+		return CrockfordBase32Charset[mod]
+	}
+	return crockfordCheckSymbols[mod-32]
+}
+
+// crockfordValue 返回字符对应数值，兼容小写与易混字符；非法字符返回 -1。
+func crockfordValue(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch c {
+	case 'O':
+		c = '0'
+	case 'I', 'L':
+		c = '1'
+	}
+	return strings.IndexByte(CrockfordBase32Charset, c)
+}
+
+func encodeRadix(n uint64, alphabet string) []byte {
+	base := uint64(len(alphabet))
+	var buf [64]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = alphabet[n%base]
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+	out := make([]byte, len(buf)-i, len(buf)-i+1)
+	copy(out, buf[i:])
+	return out
+}
+
+func decodeRadix(s string, base uint64, value func(c byte) int) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("待解码字符串为空")
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		d := value(s[i])
+		if d < 0 {
+			return 0, fmt.Errorf("非法字符 %q（位置 %d）", s[i], i)
+		}
+		if n > (^uint64(0)-uint64(d))/base {
+			return 0, fmt.Errorf("数值超出 uint64 范围")
+		}
+		n = n*base + uint64(d)
+	}
+	return n, nil
+}
@@ -0,0 +1,148 @@
+package codeutil
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allEncoders() map[string]Encoder {
+	return map[string]Encoder{
+		"base58":         Base58Encoder,
+		"base62":         Base62Encoder,
+		"base36":         Base36Encoder,
+		"crockford":      CrockfordBase32Encoder,
+		"crockfordCheck": CrockfordBase32CheckEncoder,
+	}
+}
+
+func TestEncoder_roundTripProperty(t *testing.T) {
+	for name, enc := range allEncoders() {
+		bytesOK := func(data []byte) bool {
+			got, err := enc.DecodeBytes(enc.EncodeBytes(data))
+			return err == nil && bytes.Equal(data, got)
+		}
+		if err := quick.Check(bytesOK, nil); err != nil {
+			t.Errorf("%s bytes: %v", name, err)
+		}
+
+		uintOK := func(n uint64) bool {
+			got, err := enc.DecodeUint64(enc.EncodeUint64(n))
+			return err == nil && got == n
+		}
+		if err := quick.Check(uintOK, nil); err != nil {
+			t.Errorf("%s uint64: %v", name, err)
+		}
+	}
+}
+
+func TestBase58Encoder(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	as.Equal("2NEpo7TZRRrLZSi2U", Base58Encoder.EncodeBytes([]byte("Hello World!")))
+	as.Equal("112", Base58Encoder.EncodeBytes([]byte{0, 0, 1}))
+	as.Equal("", Base58Encoder.EncodeBytes(nil))
+
+	data, err := Base58Encoder.DecodeBytes("112")
+	req.NoError(err)
+	as.Equal([]byte{0, 0, 1}, data)
+
+	_, err = Base58Encoder.DecodeBytes("0OIl")
+	as.Error(err)
+
+	as.Equal("1", Base58Encoder.EncodeUint64(0))
+	as.Equal("21", Base58Encoder.EncodeUint64(58))
+}
+
+func TestBase62AndBase36Encoder(t *testing.T) {
+	as := assert.New(t)
+	as.Equal("10", Base62Encoder.EncodeUint64(62))
+	as.Equal("zz", Base36Encoder.EncodeUint64(36*36-1))
+
+	_, err := Base36Encoder.DecodeUint64("")
+	as.Error(err)
+	_, err = Base62Encoder.DecodeUint64("zzzzzzzzzzzzzzz")
+	as.Error(err, "overflow")
+}
+
+func TestCrockfordEncoder(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	as.Equal("CSQPYRK1E8", CrockfordBase32Encoder.EncodeBytes([]byte("foobar")))
+	as.Equal("16J", CrockfordBase32Encoder.EncodeUint64(1234))
+	as.Equal("16JD", CrockfordBase32CheckEncoder.EncodeUint64(1234))
+
+	// 大小写不敏感、易混字符与连字符
+	n, err := CrockfordBase32Encoder.DecodeUint64("1-6j")
+	req.NoError(err)
+	as.Equal(uint64(1234), n)
+	n, err = CrockfordBase32Encoder.DecodeUint64("oIl")
+	req.NoError(err)
+	as.Equal(uint64(33), n)
+
+	n, err = CrockfordBase32CheckEncoder.DecodeUint64("16jd")
+	req.NoError(err)
+	as.Equal(uint64(1234), n)
+	_, err = CrockfordBase32CheckEncoder.DecodeUint64("16JE")
+	as.ErrorIs(err, ErrChecksumMismatch)
+	_, err = CrockfordBase32CheckEncoder.DecodeUint64("")
+	as.Error(err)
+
+	data, err := CrockfordBase32CheckEncoder.DecodeBytes(CrockfordBase32CheckEncoder.EncodeBytes([]byte("foobar")))
+	req.NoError(err)
+	as.Equal([]byte("foobar"), data)
+	_, err = CrockfordBase32Encoder.DecodeBytes("U")
+	as.Error(err)
+	_, err = CrockfordBase32Encoder.DecodeBytes("C")
+	as.Error(err, "invalid length")
+}
+
+func TestNewBaseNEncoder_invalid(t *testing.T) {
+	as := assert.New(t)
+	for _, alphabet := range []string{"", "a", "aba", "ab中"} {
+		e, err := NewBaseNEncoder(alphabet)
+		as.Error(err, alphabet)
+		as.Nil(e, alphabet)
+	}
+}
+
+func TestBaseNEncoder_caseInsensitive(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	n, err := Base36Encoder.DecodeUint64("ZZ")
+	req.NoError(err)
+	as.Equal(uint64(36*36-1), n)
+	data, err := Base36Encoder.DecodeBytes(strings.ToUpper(Base36Encoder.EncodeBytes([]byte("foobar"))))
+	req.NoError(err)
+	as.Equal([]byte("foobar"), data)
+
+	// 首字符为字母时前导零字节同样大小写不敏感
+	hex, err := NewBaseNEncoder("abcdefghijklmnop")
+	req.NoError(err)
+	enc := hex.EncodeBytes([]byte{0, 0, 1})
+	as.Equal("aab", enc)
+	data, err = hex.DecodeBytes(strings.ToUpper(enc))
+	req.NoError(err)
+	as.Equal([]byte{0, 0, 1}, data)
+
+	upper, err := NewBaseNEncoder("0123456789ABCDEF")
+	req.NoError(err)
+	n, err = upper.DecodeUint64("ff")
+	req.NoError(err)
+	as.Equal(uint64(255), n)
+
+	// 同时含大小写字母的字母表区分大小写
+	n, err = Base62Encoder.DecodeUint64("Z")
+	req.NoError(err)
+	as.Equal(uint64(35), n)
+	n, err = Base62Encoder.DecodeUint64("z")
+	req.NoError(err)
+	as.Equal(uint64(61), n)
+	_, err = Base58Encoder.DecodeUint64("l")
+	as.Error(err)
+}
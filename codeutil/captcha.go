@@ -0,0 +1,274 @@
+package codeutil
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/lontten/lutil/imgutil"
+)
+
+// captchaOpts 配置验证码图片；通过 CaptchaOpts() 获取默认值后链式修改。
+type captchaOpts struct {
+	width      int
+	height     int
+	length     int
+	charset    string
+	noiseLines int
+	noiseDots  int
+}
+
+// CaptchaOpts 返回默认配置：160x60，4 位数字，4 条干扰线，图片面积 1/50 的干扰点。
+func CaptchaOpts() *captchaOpts {
+	return &captchaOpts{
+		width:      160,
+		height:     60,
+		length:     4,
+		charset:    DigitCharset,
+		noiseLines: 4,
+		noiseDots:  -1,
+	}
+}
+
+// Size 设置图片宽高（像素）。
+func (o *captchaOpts) Size(width, height int) *captchaOpts {
+	o.width = width
+	o.height = height
+	return o
+}
+
+// Length 设置验证码位数，仅对 NewCaptcha 生效。
+func (o *captchaOpts) Length(n int) *captchaOpts {
+	o.length = n
+	return o
+}
+
+// Charset 设置验证码字符集，仅支持数字与大小写字母，如 FriendlyCharset、UpperNumCharset。
+func (o *captchaOpts) Charset(charset string) *captchaOpts {
+	o.charset = charset
+	return o
+}
+
+// NoiseLines 设置干扰线条数。
+func (o *captchaOpts) NoiseLines(n int) *captchaOpts {
+	o.noiseLines = n
+	return o
+}
+
+// NoiseDots 设置干扰点数量。
+func (o *captchaOpts) NoiseDots(n int) *captchaOpts {
+	o.noiseDots = n
+	return o
+}
+
+func resolveCaptchaOpts(opts ...*captchaOpts) *captchaOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return CaptchaOpts()
+	}
+	return opts[0]
+}
+
+// Captcha 验证码答案与 PNG 图片。
+type Captcha struct {
+	Answer string
+	PNG    []byte
+}
+
+// DataURI 返回可直接用于 <img src> 的 data URI。
+func (c Captcha) DataURI() (string, error) {
+	return CaptchaDataURI(c.PNG)
+}
+
+// NewCaptcha 按配置生成随机验证码（基于 GenCaptcha 同源的 crypto/rand）并渲染为 PNG。
+func NewCaptcha(opts ...*captchaOpts) (Captcha, error) {
+	o := resolveCaptchaOpts(opts...)
+	if o.length <= 0 {
+		return Captcha{}, fmt.Errorf("验证码位数必须大于 0")
+	}
+	if err := checkCaptchaText(o.charset); err != nil {
+		return Captcha{}, err
+	}
+	answer := RandomStrFromCharset(o.charset, o.length)
+	img, err := RenderCaptcha(answer, o)
+	if err != nil {
+		return Captcha{}, err
+	}
+	return Captcha{Answer: answer, PNG: img}, nil
+}
+
+// RenderCaptcha 将 text（如 GenCaptcha 的结果）绘制为带扭曲与干扰的 PNG 图片。
+func RenderCaptcha(text string, opts ...*captchaOpts) ([]byte, error) {
+	o := resolveCaptchaOpts(opts...)
+	if o.width <= 0 || o.height <= 0 {
+		return nil, fmt.Errorf("图片尺寸必须大于 0")
+	}
+	if text == "" {
+		return nil, fmt.Errorf("验证码内容为空")
+	}
+	if err := checkCaptchaText(text); err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, o.width, o.height))
+	bg := color.RGBA{R: uint8(235 + rand.IntN(20)), G: uint8(235 + rand.IntN(20)), B: uint8(235 + rand.IntN(20)), A: 255}
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = bg.R, bg.G, bg.B, bg.A
+	}
+
+	runes := []rune(text)
+	cellW := float64(o.width) / float64(len(runes))
+	for i, r := range runes {
+		drawCaptchaGlyph(img, captchaFont[r], cellW*(float64(i)+0.5), float64(o.height)/2, cellW, float64(o.height))
+	}
+
+	for i := 0; i < o.noiseLines; i++ {
+		drawCaptchaNoiseLine(img)
+	}
+	dots := o.noiseDots
+	if dots < 0 {
+		dots = o.width * o.height / 50
+	}
+	for i := 0; i < dots; i++ {
+		img.Set(rand.IntN(o.width), rand.IntN(o.height), randomCaptchaColor())
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("PNG 编码失败: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// CaptchaDataURI 将 PNG 字节转换为 data:image/png;base64 URI。
+func CaptchaDataURI(pngBytes []byte) (string, error) {
+	b64, err := imgutil.BytesToBase64(pngBytes)
+	if err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + b64, nil
+}
+
+func checkCaptchaText(text string) error {
+	if text == "" {
+		return fmt.Errorf("验证码字符集为空")
+	}
+	for _, r := range text {
+		if _, ok := captchaFont[r]; !ok {
+			return fmt.Errorf("验证码不支持字符 %q", r)
+		}
+	}
+	return nil
+}
+
+// drawCaptchaGlyph 以 (cx, cy) 为中心绘制随机旋转、缩放并正弦扭曲的字形。
+func drawCaptchaGlyph(img *image.RGBA, glyph [captchaGlyphH]uint8, cx, cy, cellW, cellH float64) {
+	scale := math.Min(cellW*0.75/captchaGlyphW, cellH*0.7/captchaGlyphH) * (0.85 + rand.Float64()*0.3)
+	cx += (rand.Float64() - 0.5) * cellW * 0.2
+	cy += (rand.Float64() - 0.5) * cellH * 0.2
+	angle := (rand.Float64() - 0.5) * 0.7
+	sin, cos := math.Sincos(angle)
+	amp := scale * (0.2 + rand.Float64()*0.25)
+	freq := 2 * math.Pi / (scale * (8 + rand.Float64()*6))
+	phase := rand.Float64() * 2 * math.Pi
+	c := randomCaptchaColor()
+
+	radius := scale * captchaGlyphH
+	bounds := img.Bounds()
+	minX, maxX := max(int(cx-radius), bounds.Min.X), min(int(cx+radius)+1, bounds.Max.X)
+	minY, maxY := max(int(cy-radius), bounds.Min.Y), min(int(cy+radius)+1, bounds.Max.Y)
+	for py := minY; py < maxY; py++ {
+		for px := minX; px < maxX; px++ {
+			dx := float64(px) - cx + amp*math.Sin(float64(py)*freq+phase)
+			dy := float64(py) - cy
+			gx := (dx*cos+dy*sin)/scale + captchaGlyphW/2.0
+			gy := (-dx*sin+dy*cos)/scale + captchaGlyphH/2.0
+			if captchaGlyphOn(glyph, int(math.Floor(gx)), int(math.Floor(gy))) {
+				img.SetRGBA(px, py, c)
+			}
+		}
+	}
+}
+
+// drawCaptchaNoiseLine 绘制一条横穿图片的正弦干扰线。
+func drawCaptchaNoiseLine(img *image.RGBA) {
+	b := img.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	y0 := rand.Float64() * h
+	amp := h * (0.1 + rand.Float64()*0.3)
+	freq := 2 * math.Pi / (w * (0.5 + rand.Float64()))
+	phase := rand.Float64() * 2 * math.Pi
+	c := randomCaptchaColor()
+	for x := b.Min.X; x < b.Max.X; x++ {
+		y := int(y0 + amp*math.Sin(float64(x)*freq+phase))
+		for t := 0; t < 2; t++ {
+			if p := image.Pt(x, y+t); p.In(b) {
+				img.SetRGBA(p.X, p.Y, c)
+			}
+		}
+	}
+}
+
+func randomCaptchaColor() color.RGBA {
+	return color.RGBA{R: uint8(rand.IntN(150)), G: uint8(rand.IntN(150)), B: uint8(rand.IntN(150)), A: 255}
+}
+
+// CaptchaStore 验证码答案存储，用于多实例部署时替换为 Redis 等实现。
+type CaptchaStore interface {
+	// Set 保存 id 对应的答案。
+	Set(id, answer string) error
+	// Get 获取答案；clear 为 true 时读取后删除（一次性校验）。不存在或已过期时返回 false。
+	Get(id string, clear bool) (string, bool)
+}
+
+// VerifyCaptcha 校验答案（忽略大小写与首尾空白），无论成功与否都会删除该验证码，防止重放与暴力猜测。
+func VerifyCaptcha(store CaptchaStore, id, answer string) bool {
+	want, ok := store.Get(id, true)
+	if !ok {
+		return false
+	}
+	return strings.EqualFold(want, strings.TrimSpace(answer))
+}
+
+// MemoryCaptchaStore 基于过期 LRU 的内存验证码存储，适用于单实例部署。
+type MemoryCaptchaStore struct {
+	mu    sync.Mutex
+	cache *expirable.LRU[string, string]
+}
+
+// NewMemoryCaptchaStore 创建内存存储；size 为最大条数（超出淘汰最久未用），ttl 为有效期，均须为正，否则返回错误。
+func NewMemoryCaptchaStore(size int, ttl time.Duration) (*MemoryCaptchaStore, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("验证码存储容量必须为正数: %d", size)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("验证码有效期必须为正数: %s", ttl)
+	}
+	return &MemoryCaptchaStore{cache: expirable.NewLRU[string, string](size, nil, ttl)}, nil
+}
+
+// Set 保存 id 对应的答案。
+func (s *MemoryCaptchaStore) Set(id, answer string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Add(id, answer)
+	return nil
+}
+
+// Get 获取答案；clear 为 true 时读取后删除。
+func (s *MemoryCaptchaStore) Get(id string, clear bool) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.cache.Get(id)
+	if ok && clear {
+		s.cache.Remove(id)
+	}
+	return v, ok
+}
@@ -0,0 +1,90 @@
+package codeutil
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCaptcha(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	c, err := NewCaptcha()
+	req.NoError(err)
+	as.Len(c.Answer, 4)
+	img, err := png.Decode(bytes.NewReader(c.PNG))
+	req.NoError(err)
+	as.Equal(160, img.Bounds().Dx())
+	as.Equal(60, img.Bounds().Dy())
+
+	uri, err := c.DataURI()
+	req.NoError(err)
+	as.True(strings.HasPrefix(uri, "data:image/png;base64,"))
+
+	c, err = NewCaptcha(CaptchaOpts().Size(240, 80).Length(6).Charset(FriendlyCharset).NoiseLines(0).NoiseDots(0))
+	req.NoError(err)
+	as.Len(c.Answer, 6)
+	img, err = png.Decode(bytes.NewReader(c.PNG))
+	req.NoError(err)
+	as.Equal(240, img.Bounds().Dx())
+}
+
+func TestRenderCaptcha(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	data, err := RenderCaptcha(GenCaptcha(4))
+	req.NoError(err)
+	as.NotEmpty(data)
+
+	_, err = RenderCaptcha("")
+	as.Error(err)
+	_, err = RenderCaptcha("中文")
+	as.Error(err)
+	_, err = RenderCaptcha("1234", CaptchaOpts().Size(0, 10))
+	as.Error(err)
+	_, err = NewCaptcha(CaptchaOpts().Charset("#$"))
+	as.Error(err)
+}
+
+func TestMemoryCaptchaStore(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	store, err := NewMemoryCaptchaStore(10, time.Minute)
+	req.NoError(err)
+	as.NoError(store.Set("a", "Ab12"))
+
+	v, ok := store.Get("a", false)
+	as.True(ok)
+	as.Equal("Ab12", v)
+
+	as.False(VerifyCaptcha(store, "missing", "Ab12"))
+	as.True(VerifyCaptcha(store, "a", " ab12 "))
+	as.False(VerifyCaptcha(store, "a", "Ab12"), "一次性校验")
+
+	_ = store.Set("b", "1234")
+	as.False(VerifyCaptcha(store, "b", "0000"))
+	as.False(VerifyCaptcha(store, "b", "1234"), "校验失败同样删除")
+
+	short, err := NewMemoryCaptchaStore(10, 20*time.Millisecond)
+	req.NoError(err)
+	_ = short.Set("c", "1234")
+	time.Sleep(50 * time.Millisecond)
+	_, ok = short.Get("c", false)
+	as.False(ok)
+
+	for _, c := range []struct {
+		size int
+		ttl  time.Duration
+	}{{0, time.Minute}, {-1, time.Minute}, {10, 0}, {10, -time.Second}} {
+		s, err := NewMemoryCaptchaStore(c.size, c.ttl)
+		as.Error(err, "%d %s", c.size, c.ttl)
+		as.Nil(s)
+	}
+}
@@ -2,7 +2,9 @@ package codeutil
 
 import (
 	"testing"
+	"time"
 
+	"github.com/lontten/lutil/clockutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -67,4 +69,9 @@ func TestRandomTimeID(t *testing.T) {
 	as.Len(id, 32)
 	numID := RandomTimeNumberID32()
 	as.Len(numID, 32)
+
+	clock := clockutil.NewFake(time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC))
+	as.Equal("20250601083000", RandomTimeBaseIDWithClock(clock, DigitCharset, 14))
+	as.Equal("2025", RandomTimeBaseIDWithClock(clock, DigitCharset, 4))
+	as.Regexp(`^20250601083000[0-9]{6}$`, RandomTimeBaseIDWithClock(clock, DigitCharset, 20))
 }
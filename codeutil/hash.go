@@ -0,0 +1,149 @@
+package codeutil
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"os"
+)
+
+// HashAlgo 哈希算法名称。
+type HashAlgo string
+
+const (
+	HashMD5    HashAlgo = "md5"
+	HashSHA1   HashAlgo = "sha1"
+	HashSHA256 HashAlgo = "sha256"
+	HashSHA512 HashAlgo = "sha512"
+
+	// HashCRC32 IEEE 多项式 CRC32，常用于压缩包、对象存储校验。
+	HashCRC32 HashAlgo = "crc32"
+	// HashCRC32C Castagnoli 多项式 CRC32（GCS、部分 S3 兼容存储使用）。
+	HashCRC32C HashAlgo = "crc32c"
+	// HashFNV64a 非加密快速哈希，适合去重、分片等场景，不可用于安全校验。
+	HashFNV64a HashAlgo = "fnv64a"
+)
+
+// New 创建对应算法的 hash.Hash，不支持的算法返回错误。
+func (a HashAlgo) New() (hash.Hash, error) {
+	switch a {
+	case HashMD5:
+		return md5.New(), nil
+	case HashSHA1:
+		return sha1.New(), nil
+	case HashSHA256:
+		return sha256.New(), nil
+	case HashSHA512:
+		return sha512.New(), nil
+	case HashCRC32:
+		return crc32.NewIEEE(), nil
+	case HashCRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case HashFNV64a:
+		return fnv.New64a(), nil
+	default:
+		return nil, fmt.Errorf("不支持的哈希算法: %q", string(a))
+	}
+}
+
+// HashSum 哈希结果，Sum 为原始摘要字节（大端序）。
+type HashSum struct {
+	Algo HashAlgo
+	Sum  []byte
+}
+
+// Hex 返回小写十六进制摘要。
+func (h HashSum) Hex() string {
+	return hex.EncodeToString(h.Sum)
+}
+
+// Base64 返回标准 Base64 摘要。
+// 算法为 MD5 时即对象存储（OSS/S3/COS）所需的 Content-MD5 头格式。
+func (h HashSum) Base64() string {
+	return base64.StdEncoding.EncodeToString(h.Sum)
+}
+
+// HashReader 流式读取 r 并计算摘要，不会将内容整体载入内存。
+func HashReader(r io.Reader, algo HashAlgo) (HashSum, error) {
+	sums, err := HashReaderMulti(r, algo)
+	if err != nil {
+		return HashSum{}, err
+	}
+	return sums[algo], nil
+}
+
+// HashFile 流式计算文件摘要。
+func HashFile(path string, algo HashAlgo) (HashSum, error) {
+	sums, err := HashFileMulti(path, algo)
+	if err != nil {
+		return HashSum{}, err
+	}
+	return sums[algo], nil
+}
+
+// HashReaderMulti 单次读取 r 同时计算多种摘要。
+// algos 不能为空，重复的算法只计算一次。
+func HashReaderMulti(r io.Reader, algos ...HashAlgo) (map[HashAlgo]HashSum, error) {
+	if len(algos) == 0 {
+		return nil, fmt.Errorf("至少需要一种哈希算法")
+	}
+	hashes := make(map[HashAlgo]hash.Hash, len(algos))
+	writers := make([]io.Writer, 0, len(algos))
+	for _, algo := range algos {
+		if _, ok := hashes[algo]; ok {
+			continue
+		}
+		h, err := algo.New()
+		if err != nil {
+			return nil, err
+		}
+		hashes[algo] = h
+		writers = append(writers, h)
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, fmt.Errorf("读取数据失败: %w", err)
+	}
+
+	result := make(map[HashAlgo]HashSum, len(hashes))
+	for algo, h := range hashes {
+		result[algo] = HashSum{Algo: algo, Sum: h.Sum(nil)}
+	}
+	return result, nil
+}
+
+// HashFileMulti 单次读取文件同时计算多种摘要。
+func HashFileMulti(path string, algos ...HashAlgo) (map[HashAlgo]HashSum, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return HashReaderMulti(f, algos...)
+}
+
+// ContentMD5 流式计算 r 的 Content-MD5（MD5 摘要的标准 Base64）。
+func ContentMD5(r io.Reader) (string, error) {
+	sum, err := HashReader(r, HashMD5)
+	if err != nil {
+		return "", err
+	}
+	return sum.Base64(), nil
+}
+
+// FileContentMD5 流式计算文件的 Content-MD5，用于上传对象存储时的完整性校验。
+func FileContentMD5(path string) (string, error) {
+	sum, err := HashFile(path, HashMD5)
+	if err != nil {
+		return "", err
+	}
+	return sum.Base64(), nil
+}
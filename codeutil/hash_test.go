@@ -0,0 +1,83 @@
+package codeutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashReader(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	cases := map[HashAlgo]string{
+		HashMD5:    "098f6bcd4621d373cade4e832627b4f6",
+		HashSHA1:   "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+		HashSHA256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		HashCRC32:  "d87f7e0c",
+		HashFNV64a: "f9e6e6ef197c2b25",
+	}
+	for algo, want := range cases {
+		sum, err := HashReader(strings.NewReader("test"), algo)
+		req.NoError(err)
+		as.Equal(want, sum.Hex(), algo)
+		as.Equal(algo, sum.Algo)
+	}
+
+	// 与内存版本一致
+	sum, err := HashReader(strings.NewReader("test"), HashMD5)
+	req.NoError(err)
+	as.Equal(MD5("test"), sum.Hex())
+
+	_, err = HashReader(strings.NewReader("test"), HashAlgo("unknown"))
+	as.Error(err)
+}
+
+func TestHashReaderMulti(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	sums, err := HashReaderMulti(strings.NewReader("test"), HashMD5, HashSHA256, HashMD5)
+	req.NoError(err)
+	as.Len(sums, 2)
+	as.Equal("098f6bcd4621d373cade4e832627b4f6", sums[HashMD5].Hex())
+	as.Equal("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", sums[HashSHA256].Hex())
+
+	_, err = HashReaderMulti(strings.NewReader("test"))
+	as.Error(err)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("boom") }
+
+func TestHashReader_readError(t *testing.T) {
+	_, err := HashReader(errReader{}, HashSHA1)
+	assert.Error(t, err)
+}
+
+func TestHashFileAndContentMD5(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	path := filepath.Join(t.TempDir(), "a.txt")
+	req.NoError(os.WriteFile(path, []byte("test"), 0644))
+
+	sum, err := HashFile(path, HashSHA512)
+	req.NoError(err)
+	as.Len(sum.Hex(), 128)
+
+	md5Base64, err := FileContentMD5(path)
+	req.NoError(err)
+	as.Equal("CY9rzUYh03PK3k6DJie09g==", md5Base64)
+
+	md5Base64, err = ContentMD5(strings.NewReader("test"))
+	req.NoError(err)
+	as.Equal("CY9rzUYh03PK3k6DJie09g==", md5Base64)
+
+	_, err = HashFile(filepath.Join(t.TempDir(), "missing"), HashMD5)
+	as.Error(err)
+}
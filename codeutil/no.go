@@ -1,7 +1,7 @@
 package codeutil
 
 import (
-	"time"
+	"github.com/lontten/lutil/clockutil"
 )
 
 // RandomTimeID32
@@ -30,7 +30,12 @@ func RandomTimeNumberID(length int) string {
 
 // RandomTimeBaseID 生成时间戳前缀加随机后缀的 ID 字符串。
 func RandomTimeBaseID(charset string, length int) string {
-	timestamp := time.Now().Format("20060102150405") // 14字符
+	return RandomTimeBaseIDWithClock(clockutil.Real, charset, length)
+}
+
+// RandomTimeBaseIDWithClock 同 RandomTimeBaseID，时间戳取自时钟 c。
+func RandomTimeBaseIDWithClock(c clockutil.Clock, charset string, length int) string {
+	timestamp := c.Now().Format("20060102150405") // 14字符
 	randomLength := length - len(timestamp)
 	if randomLength <= 0 {
 		return timestamp[:length]
@@ -0,0 +1,319 @@
+package codeutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidObfuscatedID 表示混淆 ID 无法解码或被篡改。
+var ErrInvalidObfuscatedID = errors.New("invalid obfuscated id")
+
+// Obfuscator 将数据库自增 ID 等非负整数编码为短小、非连续、可逆的字符串（Sqids 风格）。
+// 同一配置（字母表、salt、最小长度、屏蔽词）下编码结果稳定；配置变化后旧 ID 无法解码。
+// 仅用于隐藏 ID 规律，不是加密，不可用于权限校验。
+type Obfuscator struct {
+	alphabet  []byte
+	index     [256]int
+	minLength int
+	blocklist []string
+}
+
+// obfuscatorOpts 配置 Obfuscator；通过 ObfuscatorOpts() 获取默认值后链式修改。
+type obfuscatorOpts struct {
+	alphabet  string
+	salt      string
+	minLength int
+	blocklist []string
+}
+
+// ObfuscatorOpts 返回默认配置：字母表 Base62Charset，无 salt，最小长度 0，无屏蔽词。
+func ObfuscatorOpts() *obfuscatorOpts {
+	return &obfuscatorOpts{alphabet: Base62Charset}
+}
+
+// Alphabet 设置字母表，如 FriendlyCharset；要求 ASCII、不重复、至少 3 个字符。
+func (o *obfuscatorOpts) Alphabet(alphabet string) *obfuscatorOpts {
+	o.alphabet = alphabet
+	return o
+}
+
+// Salt 设置盐值，用于打乱字母表，使不同业务的编码结果互不相同。
+func (o *obfuscatorOpts) Salt(salt string) *obfuscatorOpts {
+	o.salt = salt
+	return o
+}
+
+// MinLength 设置编码结果最小长度，不足时填充。
+func (o *obfuscatorOpts) MinLength(n int) *obfuscatorOpts {
+	o.minLength = n
+	return o
+}
+
+// Blocklist 设置屏蔽词（不区分大小写），编码结果不会包含这些词。
+// 长度小于 3 或含字母表外字符的词会被忽略。
+func (o *obfuscatorOpts) Blocklist(words ...string) *obfuscatorOpts {
+	o.blocklist = words
+	return o
+}
+
+// NewObfuscator 按配置创建 Obfuscator；opts 为空时使用 ObfuscatorOpts() 默认值。
+func NewObfuscator(opts ...*obfuscatorOpts) (*Obfuscator, error) {
+	o := ObfuscatorOpts()
+	if len(opts) > 0 && opts[0] != nil {
+		o = opts[0]
+	}
+
+	if len(o.alphabet) < 3 {
+		return nil, fmt.Errorf("字母表至少需要 3 个字符")
+	}
+	alphabet := []byte(o.alphabet)
+	var seen [256]bool
+	for _, c := range alphabet {
+		if c >= 0x80 {
+			return nil, fmt.Errorf("字母表仅支持 ASCII 字符")
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("字母表包含重复字符: %q", c)
+		}
+		seen[c] = true
+	}
+	if o.minLength < 0 || o.minLength > 255 {
+		return nil, fmt.Errorf("最小长度须在 0~255 之间")
+	}
+
+	saltShuffle(alphabet, []byte(o.salt))
+	sqidsShuffle(alphabet)
+
+	ob := &Obfuscator{alphabet: alphabet, minLength: o.minLength}
+	for i := range ob.index {
+		ob.index[i] = -1
+	}
+	for i, c := range alphabet {
+		ob.index[c] = i
+	}
+
+	lowerAlphabet := strings.ToLower(o.alphabet)
+	for _, word := range o.blocklist {
+		word = strings.ToLower(word)
+		if len(word) < 3 {
+			continue
+		}
+		valid := true
+		for i := 0; i < len(word); i++ {
+			if strings.IndexByte(lowerAlphabet, word[i]) < 0 {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			ob.blocklist = append(ob.blocklist, word)
+		}
+	}
+	return ob, nil
+}
+
+// Encode 将一组非负整数编码为字符串；numbers 为空时返回空串。
+func (ob *Obfuscator) Encode(numbers ...uint64) (string, error) {
+	if len(numbers) == 0 {
+		return "", nil
+	}
+	return ob.encode(numbers, 0)
+}
+
+// Decode 将字符串解码为整数列表。
+// 输入含字母表外字符、结构非法或被篡改（重新编码结果不一致）时返回 ErrInvalidObfuscatedID。
+func (ob *Obfuscator) Decode(id string) ([]uint64, error) {
+	if id == "" {
+		return nil, ErrInvalidObfuscatedID
+	}
+	numbers, ok := ob.decode(id)
+	if !ok || len(numbers) == 0 {
+		return nil, ErrInvalidObfuscatedID
+	}
+	canonical, err := ob.encode(numbers, 0)
+	if err != nil || canonical != id {
+		return nil, ErrInvalidObfuscatedID
+	}
+	return numbers, nil
+}
+
+// EncodeUint64 编码单个整数。
+func (ob *Obfuscator) EncodeUint64(n uint64) (string, error) {
+	return ob.Encode(n)
+}
+
+// DecodeUint64 解码单个整数；解码结果不是恰好一个整数时返回 ErrInvalidObfuscatedID。
+func (ob *Obfuscator) DecodeUint64(id string) (uint64, error) {
+	numbers, err := ob.Decode(id)
+	if err != nil {
+		return 0, err
+	}
+	if len(numbers) != 1 {
+		return 0, ErrInvalidObfuscatedID
+	}
+	return numbers[0], nil
+}
+
+func (ob *Obfuscator) encode(numbers []uint64, increment int) (string, error) {
+	size := len(ob.alphabet)
+	if increment > size {
+		return "", fmt.Errorf("无法生成不含屏蔽词的编码，请调整字母表或屏蔽词")
+	}
+
+	offset := len(numbers)
+	for i, v := range numbers {
+		offset += int(ob.alphabet[v%uint64(size)]) + i
+	}
+	offset = (offset%size + increment) % size
+
+	alphabet := make([]byte, 0, size)
+	alphabet = append(alphabet, ob.alphabet[offset:]...)
+	alphabet = append(alphabet, ob.alphabet[:offset]...)
+	prefix := alphabet[0]
+	reverseBytes(alphabet)
+
+	out := []byte{prefix}
+	for i, num := range numbers {
+		out = append(out, toObfuscatedDigits(num, alphabet[1:])...)
+		if i < len(numbers)-1 {
+			out = append(out, alphabet[0])
+			sqidsShuffle(alphabet)
+		}
+	}
+
+	if len(out) < ob.minLength {
+		out = append(out, alphabet[0])
+		for len(out) < ob.minLength {
+			sqidsShuffle(alphabet)
+			n := min(ob.minLength-len(out), size)
+			out = append(out, alphabet[:n]...)
+		}
+	}
+
+	id := string(out)
+	if ob.isBlocked(id) {
+		return ob.encode(numbers, increment+1)
+	}
+	return id, nil
+}
+
+func (ob *Obfuscator) decode(id string) ([]uint64, bool) {
+	for i := 0; i < len(id); i++ {
+		if ob.index[id[i]] < 0 {
+			return nil, false
+		}
+	}
+
+	size := len(ob.alphabet)
+	offset := ob.index[id[0]]
+	alphabet := make([]byte, 0, size)
+	alphabet = append(alphabet, ob.alphabet[offset:]...)
+	alphabet = append(alphabet, ob.alphabet[:offset]...)
+	reverseBytes(alphabet)
+
+	var result []uint64
+	rest := id[1:]
+	for len(rest) > 0 {
+		separator := alphabet[0]
+		chunk, tail, found := strings.Cut(rest, string(separator))
+		if chunk == "" {
+			// 分隔符后为填充字符
+			return result, true
+		}
+		num, ok := fromObfuscatedDigits(chunk, alphabet[1:])
+		if !ok {
+			return nil, false
+		}
+		result = append(result, num)
+		if !found {
+			break
+		}
+		sqidsShuffle(alphabet)
+		rest = tail
+	}
+	return result, true
+}
+
+func (ob *Obfuscator) isBlocked(id string) bool {
+	id = strings.ToLower(id)
+	for _, word := range ob.blocklist {
+		if len(word) > len(id) {
+			continue
+		}
+		switch {
+		case len(id) <= 3 || len(word) <= 3:
+			if id == word {
+				return true
+			}
+		case strings.ContainsAny(word, DigitCharset):
+			if strings.HasPrefix(id, word) || strings.HasSuffix(id, word) {
+				return true
+			}
+		case strings.Contains(id, word):
+			return true
+		}
+	}
+	return false
+}
+
+func toObfuscatedDigits(num uint64, alphabet []byte) []byte {
+	base := uint64(len(alphabet))
+	var buf [64]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = alphabet[num%base]
+		num /= base
+		if num == 0 {
+			break
+		}
+	}
+	return buf[i:]
+}
+
+func fromObfuscatedDigits(s string, alphabet []byte) (uint64, bool) {
+	base := uint64(len(alphabet))
+	var num uint64
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(string(alphabet), s[i])
+		if d < 0 {
+			return 0, false
+		}
+		if num > (^uint64(0)-uint64(d))/base {
+			return 0, false
+		}
+		num = num*base + uint64(d)
+	}
+	return num, true
+}
+
+// sqidsShuffle 确定性打乱字母表（与 Sqids 规范一致）。
+func sqidsShuffle(chars []byte) {
+	n := len(chars)
+	for i, j := 0, n-1; j > 0; i, j = i+1, j-1 {
+		r := (i*j + int(chars[i]) + int(chars[j])) % n
+		chars[i], chars[r] = chars[r], chars[i]
+	}
+}
+
+// saltShuffle 按 salt 确定性打乱字母表（Hashids 的 consistent shuffle）。
+func saltShuffle(chars, salt []byte) {
+	if len(salt) == 0 {
+		return
+	}
+	for i, v, p := len(chars)-1, 0, 0; i > 0; i-- {
+		v %= len(salt)
+		s := int(salt[v])
+		p += s
+		j := (s + v + p) % i
+		chars[i], chars[j] = chars[j], chars[i]
+		v++
+	}
+}
+
+func reverseBytes(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,113 @@
+package codeutil
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObfuscator_sqidsCompatible(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	// 无 salt 时与 Sqids 规范的测试向量一致
+	ob, err := NewObfuscator(ObfuscatorOpts().Alphabet("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"))
+	req.NoError(err)
+
+	id, err := ob.Encode(1, 2, 3)
+	req.NoError(err)
+	as.Equal("86Rf07", id)
+
+	numbers, err := ob.Decode("86Rf07")
+	req.NoError(err)
+	as.Equal([]uint64{1, 2, 3}, numbers)
+}
+
+func TestObfuscator_roundTrip(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	ob, err := NewObfuscator(ObfuscatorOpts().Alphabet(FriendlyCharset).Salt("order").MinLength(8))
+	req.NoError(err)
+
+	for _, n := range []uint64{0, 1, 2, 61, 62, 1000, 123456789, math.MaxUint64} {
+		id, err := ob.EncodeUint64(n)
+		req.NoError(err)
+		as.GreaterOrEqual(len(id), 8)
+		got, err := ob.DecodeUint64(id)
+		req.NoError(err)
+		as.Equal(n, got)
+	}
+
+	id, err := ob.Encode(7, 0, math.MaxUint64)
+	req.NoError(err)
+	numbers, err := ob.Decode(id)
+	req.NoError(err)
+	as.Equal([]uint64{7, 0, math.MaxUint64}, numbers)
+
+	empty, err := ob.Encode()
+	req.NoError(err)
+	as.Empty(empty)
+}
+
+func TestObfuscator_salt(t *testing.T) {
+	as := assert.New(t)
+	a, _ := NewObfuscator(ObfuscatorOpts().Salt("a"))
+	b, _ := NewObfuscator(ObfuscatorOpts().Salt("b"))
+	idA, _ := a.EncodeUint64(42)
+	idB, _ := b.EncodeUint64(42)
+	as.NotEqual(idA, idB)
+
+	n, err := a.DecodeUint64(idA)
+	as.NoError(err)
+	as.Equal(uint64(42), n)
+}
+
+func TestObfuscator_tampered(t *testing.T) {
+	as := assert.New(t)
+	ob, _ := NewObfuscator(ObfuscatorOpts().MinLength(10))
+	id, _ := ob.EncodeUint64(12345)
+
+	_, err := ob.Decode("")
+	as.ErrorIs(err, ErrInvalidObfuscatedID)
+	_, err = ob.Decode(id + "*")
+	as.ErrorIs(err, ErrInvalidObfuscatedID)
+
+	tampered := []byte(id)
+	tampered[len(tampered)-1] = Base62Charset[(strings.IndexByte(Base62Charset, tampered[len(tampered)-1])+1)%62]
+	_, err = ob.Decode(string(tampered))
+	as.ErrorIs(err, ErrInvalidObfuscatedID)
+
+	two, _ := ob.Encode(1, 2)
+	_, err = ob.DecodeUint64(two)
+	as.ErrorIs(err, ErrInvalidObfuscatedID)
+}
+
+func TestObfuscator_blocklist(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	plain, _ := NewObfuscator()
+	id, _ := plain.Encode(1, 2, 3)
+
+	ob, err := NewObfuscator(ObfuscatorOpts().Blocklist(id, "ab"))
+	req.NoError(err)
+	blockedID, err := ob.Encode(1, 2, 3)
+	req.NoError(err)
+	as.NotEqual(id, blockedID)
+	numbers, err := ob.Decode(blockedID)
+	req.NoError(err)
+	as.Equal([]uint64{1, 2, 3}, numbers)
+}
+
+func TestNewObfuscator_invalid(t *testing.T) {
+	as := assert.New(t)
+	_, err := NewObfuscator(ObfuscatorOpts().Alphabet("ab"))
+	as.Error(err)
+	_, err = NewObfuscator(ObfuscatorOpts().Alphabet("abca"))
+	as.Error(err)
+	_, err = NewObfuscator(ObfuscatorOpts().Alphabet("abc中"))
+	as.Error(err)
+	_, err = NewObfuscator(ObfuscatorOpts().MinLength(-1))
+	as.Error(err)
+}
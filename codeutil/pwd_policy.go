@@ -0,0 +1,588 @@
+package codeutil
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed data/common_passwords.txt
+var commonPasswordsRaw string
+
+var (
+	commonPasswordsOnce sync.Once
+	commonPasswords     map[string]int // 小写密码 → 频率排名（从 1 开始）
+	commonPasswordMax   int            // 常见密码的最大字符数，限制子串扫描范围
+)
+
+func commonPasswordRank(s string) int {
+	commonPasswordsOnce.Do(func() {
+		commonPasswords = make(map[string]int)
+		rank := 0
+		for _, line := range strings.Split(commonPasswordsRaw, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			rank++
+			if _, ok := commonPasswords[line]; !ok {
+				commonPasswords[line] = rank
+				commonPasswordMax = max(commonPasswordMax, len([]rune(line)))
+			}
+		}
+	})
+	return commonPasswords[s]
+}
+
+// IsCommonPassword 判断密码（忽略大小写与常见 leet 替换，如 p@ssw0rd）是否在内建常见弱密码表中。
+func IsCommonPassword(password string) bool {
+	low := strings.ToLower(password)
+	return commonPasswordRank(low) > 0 || commonPasswordRank(unLeet(low)) > 0
+}
+
+// 违规代码，PasswordViolation.Code 取值。
+const (
+	PwdTooShort       = "too_short"
+	PwdTooLong        = "too_long"
+	PwdMissingUpper   = "missing_upper"
+	PwdMissingLower   = "missing_lower"
+	PwdMissingDigit   = "missing_digit"
+	PwdMissingSymbol  = "missing_symbol"
+	PwdTooFewClasses  = "too_few_classes"
+	PwdCommon         = "common_password"
+	PwdRepeat         = "repeat"
+	PwdSequence       = "sequence"
+	PwdKeyboard       = "keyboard_pattern"
+	PwdTooWeak        = "too_weak"
+	PwdContainsSpaces = "contains_spaces"
+)
+
+// PasswordViolation 密码策略违规项，Message 为中文提示，MessageEn 为英文提示，可直接用于表单校验。
+type PasswordViolation struct {
+	Code      string
+	Message   string
+	MessageEn string
+}
+
+// Error 返回中文提示，便于直接作为 error 使用。
+func (v PasswordViolation) Error() string {
+	return v.Message
+}
+
+// PasswordPolicy 密码策略；零值字段表示不限制。
+type PasswordPolicy struct {
+	MinLength     int  // 最小长度（按字符数）
+	MaxLength     int  // 最大长度，0 不限
+	RequireUpper  bool // 必须包含大写字母
+	RequireLower  bool // 必须包含小写字母
+	RequireDigit  bool // 必须包含数字
+	RequireSymbol bool // 必须包含特殊符号
+	MinClasses    int  // 至少包含几类字符（大写、小写、数字、符号）
+	ForbidCommon  bool // 禁止常见弱密码
+	ForbidSpaces  bool // 禁止空白字符
+	MaxRepeat     int  // 同一字符（不区分大小写）最多连续出现次数，0 不限
+	// ForbidSequence 禁止 4 位及以上连续字母/数字，如 abcd、4321
+	ForbidSequence bool
+	// ForbidKeyboard 禁止 4 位及以上键盘连续按键，如 qwer、1qaz
+	ForbidKeyboard bool
+	MinScore       int // 最低强度分（0~4，见 EvaluatePassword）
+}
+
+// DefaultPasswordPolicy 返回常用默认策略：8~64 位，至少包含三类字符，
+// 禁止常见密码、空白、3 次以上重复、连续序列与键盘序列，强度分不低于 2。
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:      8,
+		MaxLength:      64,
+		MinClasses:     3,
+		ForbidCommon:   true,
+		ForbidSpaces:   true,
+		MaxRepeat:      3,
+		ForbidSequence: true,
+		ForbidKeyboard: true,
+		MinScore:       2,
+	}
+}
+
+// Check 按策略校验密码，返回全部违规项；满足策略时返回 nil。
+// 超过 MaxLength 时只返回 PwdTooLong，不再做模式分析，避免超长输入消耗大量计算。
+func (p PasswordPolicy) Check(password string) []PasswordViolation {
+	var out []PasswordViolation
+	add := func(code, zh, en string) {
+		out = append(out, PasswordViolation{Code: code, Message: zh, MessageEn: en})
+	}
+
+	runes := []rune(password)
+	if len(runes) < p.MinLength {
+		add(PwdTooShort,
+			fmt.Sprintf("密码长度不能少于 %d 位", p.MinLength),
+			fmt.Sprintf("Password must be at least %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && len(runes) > p.MaxLength {
+		add(PwdTooLong,
+			fmt.Sprintf("密码长度不能超过 %d 位", p.MaxLength),
+			fmt.Sprintf("Password must be at most %d characters", p.MaxLength))
+		return out
+	}
+
+	hasUpper, hasLower, hasDigit, hasSymbol, hasSpace := charClasses(runes)
+	if p.ForbidSpaces && hasSpace {
+		add(PwdContainsSpaces, "密码不能包含空格", "Password must not contain spaces")
+	}
+	if p.RequireUpper && !hasUpper {
+		add(PwdMissingUpper, "密码必须包含大写字母", "Password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		add(PwdMissingLower, "密码必须包含小写字母", "Password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		add(PwdMissingDigit, "密码必须包含数字", "Password must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		add(PwdMissingSymbol, "密码必须包含特殊符号", "Password must contain a symbol")
+	}
+	classes := countTrue(hasUpper, hasLower, hasDigit, hasSymbol)
+	if classes < p.MinClasses {
+		add(PwdTooFewClasses,
+			fmt.Sprintf("密码须包含大写字母、小写字母、数字、特殊符号中的至少 %d 类", p.MinClasses),
+			fmt.Sprintf("Password must contain at least %d of: uppercase, lowercase, digits, symbols", p.MinClasses))
+	}
+
+	if p.ForbidCommon && IsCommonPassword(password) {
+		add(PwdCommon, "密码过于常见，请更换", "Password is too common")
+	}
+
+	repeat := p.MaxRepeat > 0 && longestRun(runes) > p.MaxRepeat
+	var sequence, keyboard bool
+	for _, m := range findPasswordPatterns(runes) {
+		switch m.Kind {
+		case PatternSequence:
+			if p.ForbidSequence && m.End-m.Start >= 4 {
+				sequence = true
+			}
+		case PatternKeyboard:
+			if p.ForbidKeyboard && m.End-m.Start >= 4 {
+				keyboard = true
+			}
+		}
+	}
+	if repeat {
+		add(PwdRepeat,
+			fmt.Sprintf("同一字符不能连续出现超过 %d 次", p.MaxRepeat),
+			fmt.Sprintf("The same character must not repeat more than %d times in a row", p.MaxRepeat))
+	}
+	if sequence {
+		add(PwdSequence, "密码不能包含连续的字母或数字，如 abcd、1234", "Password must not contain sequences such as abcd or 1234")
+	}
+	if keyboard {
+		add(PwdKeyboard, "密码不能包含键盘连续按键，如 qwer、1qaz", "Password must not contain keyboard patterns such as qwer or 1qaz")
+	}
+
+	if p.MinScore > 0 {
+		if s := EvaluatePassword(password); s.Score < p.MinScore {
+			add(PwdTooWeak, "密码强度不足", "Password is too weak")
+		}
+	}
+	return out
+}
+
+// GeneratePassword 基于 RandomStrFromCharset 生成满足策略的随机密码。
+// 长度取 MinLength 与 16 的较大值（受 MaxLength 限制）；策略无法满足时返回错误。
+func GeneratePassword(policy PasswordPolicy) (string, error) {
+	length := max(policy.MinLength, 16)
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		length = policy.MaxLength
+	}
+	if length <= 0 {
+		return "", fmt.Errorf("密码长度必须大于 0")
+	}
+
+	// 始终包含大小写字母与数字，每类至少一个
+	required := []string{LowerCharset, UpperCharset, DigitCharset}
+	if policy.RequireSymbol || policy.MinClasses >= 4 {
+		required = append(required, SymbolCharset)
+	}
+	if len(required) > length {
+		return "", fmt.Errorf("密码长度 %d 不足以包含 %d 类字符", length, len(required))
+	}
+	all := strings.Join(required, "")
+
+	for attempt := 0; attempt < 100; attempt++ {
+		buf := make([]rune, 0, length)
+		for _, charset := range required {
+			buf = append(buf, []rune(RandomStrFromCharset(charset, 1))...)
+		}
+		if rest := length - len(buf); rest > 0 {
+			buf = append(buf, []rune(RandomStrFromCharset(all, rest))...)
+		}
+		secureShuffle(buf)
+		pwd := string(buf)
+		if len(policy.Check(pwd)) == 0 {
+			return pwd, nil
+		}
+	}
+	return "", fmt.Errorf("无法生成满足策略的密码，请检查策略配置")
+}
+
+// PatternKind 密码中识别出的弱模式类型。
+type PatternKind string
+
+const (
+	PatternDictionary PatternKind = "dictionary" // 常见密码（含 leet 替换）
+	PatternRepeat     PatternKind = "repeat"     // 重复，如 aaa、abcabc
+	PatternSequence   PatternKind = "sequence"   // 连续字母数字，如 abc、321
+	PatternKeyboard   PatternKind = "keyboard"   // 键盘序列，如 qwer、1qaz
+	PatternBrute      PatternKind = "bruteforce" // 无规律字符
+)
+
+// PasswordPattern 密码中的一段模式，[Start, End) 为字符（rune）下标。
+type PasswordPattern struct {
+	Kind    PatternKind
+	Token   string
+	Start   int
+	End     int
+	Entropy float64
+}
+
+// PasswordStrength 密码强度评估结果。
+type PasswordStrength struct {
+	Score    int     // 0~4：0 极弱、1 弱、2 一般、3 强、4 很强
+	Entropy  float64 // 估算熵（bit），log2(猜测次数)
+	Patterns []PasswordPattern
+}
+
+// EvaluatePassword 估算密码强度（zxcvbn 风格）。
+// 识别常见密码、重复、连续序列与键盘序列，对其余字符按字符集暴力破解估算，
+// 取熵最小的分解方式；Score 按猜测次数 10^3/10^6/10^8/10^10 分档。
+// 只分析前 maxPatternRunes 个字符中的模式，其后的字符按暴力破解计。
+func EvaluatePassword(password string) PasswordStrength {
+	runes := []rune(password)
+	n := len(runes)
+	if n == 0 {
+		return PasswordStrength{}
+	}
+
+	bruteBits := math.Log2(float64(charsetCardinality(runes)))
+	matches := findPasswordPatterns(runes)
+	byEnd := make(map[int][]int, len(matches))
+	for i, m := range matches {
+		byEnd[m.End] = append(byEnd[m.End], i)
+	}
+
+	dp := make([]float64, n+1)
+	back := make([]int, n+1) // -1 表示该位置按暴力字符计
+	for i := 1; i <= n; i++ {
+		dp[i] = dp[i-1] + bruteBits
+		back[i] = -1
+		for _, mi := range byEnd[i] {
+			m := matches[mi]
+			if v := dp[m.Start] + m.Entropy; v < dp[i] {
+				dp[i] = v
+				back[i] = mi
+			}
+		}
+	}
+
+	var patterns []PasswordPattern
+	for i := n; i > 0; {
+		if back[i] >= 0 {
+			m := matches[back[i]]
+			patterns = append(patterns, m)
+			i = m.Start
+			continue
+		}
+		j := i
+		for j > 0 && back[j] < 0 {
+			j--
+		}
+		patterns = append(patterns, PasswordPattern{
+			Kind: PatternBrute, Token: string(runes[j:i]), Start: j, End: i,
+			Entropy: float64(i-j) * bruteBits,
+		})
+		i = j
+	}
+	for l, r := 0, len(patterns)-1; l < r; l, r = l+1, r-1 {
+		patterns[l], patterns[r] = patterns[r], patterns[l]
+	}
+	// 多段组合需额外猜测组合方式
+	entropy := dp[n] + math.Log2(float64(len(patterns)))
+
+	return PasswordStrength{
+		Score:    scoreFromEntropy(entropy),
+		Entropy:  entropy,
+		Patterns: patterns,
+	}
+}
+
+func scoreFromEntropy(bits float64) int {
+	log10Guesses := bits * math.Log10(2)
+	switch {
+	case log10Guesses < 3:
+		return 0
+	case log10Guesses < 6:
+		return 1
+	case log10Guesses < 8:
+		return 2
+	case log10Guesses < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// keyboardPaths 键盘相邻按键路径（QWERTY 行与列）。
+var keyboardPaths = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+	"1qaz2wsx3edc4rfv5tgb6yhn7ujm8ik,9ol.0p;/",
+	"zaq1xsw2cde3vfr4bgt5nhy6mju7,ki8.lo9/;p0",
+}
+
+var leetTable = map[rune]rune{
+	'4': 'a', '@': 'a', '3': 'e', '1': 'i', '!': 'i',
+	'0': 'o', '$': 's', '5': 's', '7': 't', '+': 't',
+}
+
+func unLeet(s string) string {
+	return strings.Map(func(r rune) rune {
+		if v, ok := leetTable[r]; ok {
+			return v
+		}
+		return r
+	}, s)
+}
+
+// maxPatternRunes 模式分析的最大字符数。部分模式的识别为 O(n²) 以上，
+// 超长输入只分析前缀，避免被用于消耗服务端计算资源。
+const maxPatternRunes = 128
+
+// longestRun 返回同一字符（不区分大小写）连续出现的最大次数。
+func longestRun(runes []rune) int {
+	best, cur := 0, 0
+	for i, r := range runes {
+		if i > 0 && unicode.ToLower(r) == unicode.ToLower(runes[i-1]) {
+			cur++
+		} else {
+			cur = 1
+		}
+		best = max(best, cur)
+	}
+	return best
+}
+
+// findPasswordPatterns 识别密码中的弱模式，只分析前 maxPatternRunes 个字符。
+func findPasswordPatterns(runes []rune) []PasswordPattern {
+	if len(runes) > maxPatternRunes {
+		runes = runes[:maxPatternRunes]
+	}
+	n := len(runes)
+	low := make([]rune, n)
+	for i, r := range runes {
+		low[i] = unicode.ToLower(r)
+	}
+	var out []PasswordPattern
+
+	// 常见密码子串，长度不超过表中最长的密码
+	commonPasswordRank("")
+	for i := 0; i < n; i++ {
+		for j := i + 3; j <= min(n, i+commonPasswordMax); j++ {
+			if j-i < 4 && !(i == 0 && j == n) {
+				continue
+			}
+			token := string(low[i:j])
+			rank := commonPasswordRank(token)
+			extra := 0.0
+			if rank == 0 {
+				if rank = commonPasswordRank(unLeet(token)); rank > 0 {
+					extra++
+				}
+			}
+			if rank == 0 {
+				continue
+			}
+			if string(runes[i:j]) != token {
+				extra++
+			}
+			out = append(out, PasswordPattern{
+				Kind: PatternDictionary, Token: string(runes[i:j]), Start: i, End: j,
+				Entropy: math.Log2(float64(rank)) + extra,
+			})
+		}
+	}
+
+	// 单字符重复
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && low[j] == low[i] {
+			j++
+		}
+		if j-i >= 3 {
+			out = append(out, PasswordPattern{
+				Kind: PatternRepeat, Token: string(runes[i:j]), Start: i, End: j,
+				Entropy: math.Log2(float64(charsetCardinality(runes[i:i+1]))) + math.Log2(float64(j-i)),
+			})
+		}
+		i = j
+	}
+
+	// 片段重复，如 abcabc
+	for i := 0; i < n; i++ {
+		for k := 2; i+2*k <= n; k++ {
+			reps := 1
+			for i+(reps+1)*k <= n && string(low[i+reps*k:i+(reps+1)*k]) == string(low[i:i+k]) {
+				reps++
+			}
+			if reps >= 2 {
+				blockBits := float64(k) * math.Log2(float64(charsetCardinality(runes[i:i+k])))
+				out = append(out, PasswordPattern{
+					Kind: PatternRepeat, Token: string(runes[i : i+reps*k]), Start: i, End: i + reps*k,
+					Entropy: blockBits + math.Log2(float64(reps)),
+				})
+			}
+		}
+	}
+
+	// 连续字母/数字
+	for i := 0; i < n-1; {
+		delta := low[i+1] - low[i]
+		if (delta != 1 && delta != -1) || !sameSeqClass(low[i], low[i+1]) {
+			i++
+			continue
+		}
+		j := i + 2
+		for j < n && low[j]-low[j-1] == delta && sameSeqClass(low[j-1], low[j]) {
+			j++
+		}
+		if j-i >= 3 {
+			space := 26.0
+			if unicode.IsDigit(low[i]) {
+				space = 10
+			}
+			bits := math.Log2(space) + math.Log2(float64(j-i))
+			if delta < 0 {
+				bits++
+			}
+			out = append(out, PasswordPattern{
+				Kind: PatternSequence, Token: string(runes[i:j]), Start: i, End: j, Entropy: bits,
+			})
+		}
+		i = j - 1
+	}
+
+	// 键盘序列
+	for i := 0; i < n; i++ {
+		best := 0
+		for _, path := range keyboardPaths {
+			for _, p := range []string{path, reverseString(path)} {
+				for k := best + 1; i+k <= n; k++ {
+					if !strings.Contains(p, string(low[i:i+k])) {
+						break
+					}
+					best = k
+				}
+			}
+		}
+		if best >= 4 {
+			out = append(out, PasswordPattern{
+				Kind: PatternKeyboard, Token: string(runes[i : i+best]), Start: i, End: i + best,
+				Entropy: math.Log2(47) + math.Log2(float64(best)),
+			})
+		}
+	}
+	return out
+}
+
+func sameSeqClass(a, b rune) bool {
+	isLetter := func(r rune) bool { return r >= 'a' && r <= 'z' }
+	isDigit := func(r rune) bool { return r >= '0' && r <= '9' }
+	return (isLetter(a) && isLetter(b)) || (isDigit(a) && isDigit(b))
+}
+
+func charClasses(runes []rune) (upper, lower, digit, symbol, space bool) {
+	for _, r := range runes {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	return
+}
+
+// charsetCardinality 估算暴力破解需要遍历的字符集大小。
+func charsetCardinality(runes []rune) int {
+	size := 0
+	var upper, lower, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < 0x80:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	if upper {
+		size += 26
+	}
+	if lower {
+		size += 26
+	}
+	if digit {
+		size += 10
+	}
+	if symbol {
+		size += 33
+	}
+	if other {
+		size += 100
+	}
+	return max(size, 2)
+}
+
+func countTrue(bs ...bool) int {
+	n := 0
+	for _, b := range bs {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+func reverseString(s string) string {
+	b := []byte(s)
+	reverseBytes(b)
+	return string(b)
+}
+
+// secureShuffle 使用 crypto/rand 打乱切片（Fisher–Yates）。
+func secureShuffle(buf []rune) {
+	for i := len(buf) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			panic("crypto/rand unavailable: " + err.Error())
+		}
+		j := int(n.Int64())
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+}
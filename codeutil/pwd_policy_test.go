@@ -0,0 +1,119 @@
+package codeutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violationCodes(vs []PasswordViolation) []string {
+	codes := make([]string, 0, len(vs))
+	for _, v := range vs {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func TestIsCommonPassword(t *testing.T) {
+	as := assert.New(t)
+	as.True(IsCommonPassword("123456"))
+	as.True(IsCommonPassword("Password"))
+	as.True(IsCommonPassword("P@ssw0rd"))
+	as.True(IsCommonPassword("woaini1314"))
+	as.False(IsCommonPassword("x7#Kq9!mZ2"))
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	as := assert.New(t)
+	policy := DefaultPasswordPolicy()
+
+	as.Empty(policy.Check("Tr0ub4dor&3x!"))
+
+	codes := violationCodes(policy.Check("abc"))
+	as.Contains(codes, PwdTooShort)
+	as.Contains(codes, PwdTooFewClasses)
+	as.Contains(codes, PwdTooWeak)
+
+	as.Contains(violationCodes(policy.Check("Password1")), PwdCommon)
+	as.Contains(violationCodes(policy.Check("Xy7aaaa#Kp")), PwdRepeat)
+	as.Contains(violationCodes(policy.Check("Zx#9abcdQ")), PwdSequence)
+	as.Contains(violationCodes(policy.Check("Zx#9!Qwer8")), PwdKeyboard)
+	as.Contains(violationCodes(policy.Check("1qaz@WSX")), PwdKeyboard)
+	as.Contains(violationCodes(policy.Check("Ab1 cd2Ef3")), PwdContainsSpaces)
+
+	strict := PasswordPolicy{MinLength: 4, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+	codes = violationCodes(strict.Check("abcd"))
+	as.ElementsMatch([]string{PwdMissingUpper, PwdMissingDigit, PwdMissingSymbol}, codes)
+
+	vs := DefaultPasswordPolicy().Check("short")
+	as.NotEmpty(vs[0].Message)
+	as.NotEmpty(vs[0].MessageEn)
+	as.Equal(vs[0].Message, vs[0].Error())
+}
+
+func TestEvaluatePassword(t *testing.T) {
+	as := assert.New(t)
+	as.Equal(0, EvaluatePassword("").Score)
+	as.Equal(0, EvaluatePassword("123456").Score)
+	as.Equal(0, EvaluatePassword("password").Score)
+	as.LessOrEqual(EvaluatePassword("qwertyuiop").Score, 1)
+	as.LessOrEqual(EvaluatePassword("aaaaaaaaaaaa").Score, 1)
+	as.Equal(4, EvaluatePassword("k8#Lq2!vZ9@mX4").Score)
+
+	weak := EvaluatePassword("p@ssw0rd")
+	strong := EvaluatePassword("p@ssw0rdX7#kQ")
+	as.Greater(strong.Entropy, weak.Entropy)
+
+	s := EvaluatePassword("abcdXq9#")
+	as.Equal(PatternSequence, s.Patterns[0].Kind)
+	as.Equal("abcd", s.Patterns[0].Token)
+}
+
+func TestGeneratePassword(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	policy := DefaultPasswordPolicy()
+	for i := 0; i < 20; i++ {
+		pwd, err := GeneratePassword(policy)
+		req.NoError(err)
+		as.Len(pwd, 16)
+		as.Empty(policy.Check(pwd))
+	}
+
+	symbol := PasswordPolicy{MinLength: 24, RequireSymbol: true, MinClasses: 4, ForbidCommon: true, MinScore: 4}
+	pwd, err := GeneratePassword(symbol)
+	req.NoError(err)
+	as.Len(pwd, 24)
+	as.Empty(symbol.Check(pwd))
+
+	_, err = GeneratePassword(PasswordPolicy{MaxLength: 2})
+	as.Error(err)
+}
+
+func TestPasswordPolicy_MaxRepeat(t *testing.T) {
+	as := assert.New(t)
+	as.Contains(violationCodes(PasswordPolicy{MaxRepeat: 1}.Check("aab")), PwdRepeat)
+	as.Empty(PasswordPolicy{MaxRepeat: 1}.Check("abab"))
+	as.Empty(PasswordPolicy{MaxRepeat: 2}.Check("aab"))
+	as.Contains(violationCodes(PasswordPolicy{MaxRepeat: 2}.Check("xaAa")), PwdRepeat)
+	as.Empty(PasswordPolicy{}.Check("aaaaaa"))
+}
+
+func TestPasswordPolicy_LongInput(t *testing.T) {
+	as := assert.New(t)
+	long := strings.Repeat("Ab1#xY9!", 1000)
+
+	start := time.Now()
+	as.Equal([]string{PwdTooLong}, violationCodes(DefaultPasswordPolicy().Check(long)))
+
+	// 不限长度时只分析前缀，超长输入也应很快完成
+	unlimited := DefaultPasswordPolicy()
+	unlimited.MaxLength = 0
+	unlimited.Check(long)
+	as.Equal(4, EvaluatePassword(long).Score)
+	as.Less(time.Since(start), 2*time.Second)
+}
@@ -41,6 +41,12 @@ const (
 	// Base58（比特币地址风格，去掉 0OIl）
 	Base58Charset = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
 
+	// Base36（数字 + 小写字母，大小写不敏感场景）
+	Base36Charset = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+	// Crockford Base32（去掉 ILOU，人工抄录友好）
+	CrockfordBase32Charset = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
 	// 易读字母数字（去掉 0O1lI）
 	FriendlyCharset = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
 
@@ -52,6 +58,13 @@ const (
 
 	// 仅小写字母 + 数字（文件名、容器名）
 	LowerNumCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	// 大写字母、小写字母
+	UpperCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	LowerCharset = "abcdefghijklmnopqrstuvwxyz"
+
+	// 密码常用特殊符号（不含空格与引号，便于输入与转义）
+	SymbolCharset = "!@#$%^&*()-_=+[]{};:,.?/~"
 )
 
 // RandomStr 生成包含大小写字母与数字的随机字符串。
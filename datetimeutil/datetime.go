@@ -1,7 +1,10 @@
-// Package datetimeutil 提供 LocalDateTime 的比较与聚合工具。
+// Package datetimeutil 提供 LocalDateTime 的比较、聚合、周期边界、相对时间、宽松解析、RRULE 重复规则与时区转换工具。
 package datetimeutil
 
-import "github.com/lontten/lcore/v2/types"
+import (
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
+)
 
 // Max 返回最大值，至少需要一个参数
 func Max(list ...types.LocalDateTime) types.LocalDateTime {
@@ -61,7 +64,12 @@ func MinP(list ...*types.LocalDateTime) *types.LocalDateTime {
 
 // MaxNow 从指针列表中返回最大值，以当前时间为基准，忽略nil
 func MaxNow(list ...*types.LocalDateTime) types.LocalDateTime {
-	m := types.NowDateTime()
+	return MaxNowWithClock(clockutil.Real, list...)
+}
+
+// MaxNowWithClock 同 MaxNow，以时钟 c 的当前时间为基准
+func MaxNowWithClock(c clockutil.Clock, list ...*types.LocalDateTime) types.LocalDateTime {
+	m := clockutil.NowDateTime(c)
 	for _, v := range list {
 		if v == nil {
 			continue
@@ -75,7 +83,12 @@ func MaxNow(list ...*types.LocalDateTime) types.LocalDateTime {
 
 // MinNow 从指针列表中返回最小值，以当前时间为基准，忽略nil
 func MinNow(list ...*types.LocalDateTime) types.LocalDateTime {
-	n := types.NowDateTime()
+	return MinNowWithClock(clockutil.Real, list...)
+}
+
+// MinNowWithClock 同 MinNow，以时钟 c 的当前时间为基准
+func MinNowWithClock(c clockutil.Clock, list ...*types.LocalDateTime) types.LocalDateTime {
+	n := clockutil.NowDateTime(c)
 	for _, v := range list {
 		if v == nil {
 			continue
@@ -89,7 +102,12 @@ func MinNow(list ...*types.LocalDateTime) types.LocalDateTime {
 
 // MaxNowP 从指针列表中返回最大值的指针，以当前时间为基准，忽略nil
 func MaxNowP(list ...*types.LocalDateTime) *types.LocalDateTime {
-	m := types.NowDateTime()
+	return MaxNowPWithClock(clockutil.Real, list...)
+}
+
+// MaxNowPWithClock 同 MaxNowP，以时钟 c 的当前时间为基准
+func MaxNowPWithClock(c clockutil.Clock, list ...*types.LocalDateTime) *types.LocalDateTime {
+	m := clockutil.NowDateTime(c)
 	for _, v := range list {
 		if v == nil {
 			continue
@@ -103,7 +121,12 @@ func MaxNowP(list ...*types.LocalDateTime) *types.LocalDateTime {
 
 // MinNowP 从指针列表中返回最小值的指针，以当前时间为基准，忽略nil
 func MinNowP(list ...*types.LocalDateTime) *types.LocalDateTime {
-	n := types.NowDateTime()
+	return MinNowPWithClock(clockutil.Real, list...)
+}
+
+// MinNowPWithClock 同 MinNowP，以时钟 c 的当前时间为基准
+func MinNowPWithClock(c clockutil.Clock, list ...*types.LocalDateTime) *types.LocalDateTime {
+	n := clockutil.NowDateTime(c)
 	for _, v := range list {
 		if v == nil {
 			continue
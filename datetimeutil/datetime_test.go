@@ -2,8 +2,10 @@ package datetimeutil
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -11,6 +13,20 @@ func mustDT(y, m, d, h, min, s int) types.LocalDateTime {
 	return types.LocalDateTimeOfYmdHms(y, m, d, h, min, s)
 }
 
+// setLocal 在测试期间将 time.Local 设为 name 时区，结束后恢复。
+// time.Local 是全局变量，调用它的测试不能使用 t.Parallel。
+func setLocal(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = old })
+	return loc
+}
+
 func TestMaxMin(t *testing.T) {
 	as := assert.New(t)
 	d1 := mustDT(2024, 1, 1, 0, 0, 0)
@@ -59,3 +75,23 @@ func TestIsNowRL(t *testing.T) {
 	as.True(IsNowR(&future))
 	as.True(IsNowL(&past))
 }
+
+func TestNowWithClock(t *testing.T) {
+	as := assert.New(t)
+	clock := clockutil.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local))
+	v := mustDT(2025, 6, 1, 12, 30, 0)
+
+	as.True(IsNowRWithClock(clock, &v))
+	as.Equal(mustDT(2025, 6, 1, 12, 0, 0), MinNowWithClock(clock, &v))
+	as.Equal(v, *MaxNowPWithClock(clock, &v))
+
+	clock.Advance(time.Hour)
+	as.True(IsNowLWithClock(clock, &v))
+	as.Equal(mustDT(2025, 6, 1, 13, 0, 0), MaxNowWithClock(clock, &v))
+	as.Equal(v, *MinNowPWithClock(clock, nil, &v))
+
+	got, err := ParseRelativeWithClock("3天后", clock)
+	as.NoError(err)
+	as.Equal(mustDT(2025, 6, 4, 13, 0, 0), got)
+	as.Equal("30分钟前", HumanizeWithClock(v, clock, LocaleZh))
+}
@@ -0,0 +1,154 @@
+package datetimeutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
+)
+
+// Locale 相对时间的语言。
+type Locale string
+
+const (
+	LocaleZh Locale = "zh" // 简体中文，如「3分钟前」「昨天 14:20」
+	LocaleEn Locale = "en" // 英文，如「3 minutes ago」「yesterday at 14:20」
+)
+
+// isEn 判断是否英文；以 en 开头（如 en-US）视为英文，其余均按中文处理。
+func (l Locale) isEn() bool {
+	return strings.HasPrefix(strings.ToLower(string(l)), "en")
+}
+
+type humanizeOpts struct {
+	justNow   time.Duration
+	hourLimit time.Duration
+	dayLimit  int
+	calendar  bool
+}
+
+// HumanizeOpts 创建 Humanize 选项：1 分钟内为「刚刚」，当天 24 小时内按小时，
+// 相差 1~2 个自然日显示「昨天/前天 HH:mm」，7 天内按天，其余显示日期。
+func HumanizeOpts() *humanizeOpts {
+	return &humanizeOpts{
+		justNow:   time.Minute,
+		hourLimit: 24 * time.Hour,
+		dayLimit:  7,
+		calendar:  true,
+	}
+}
+
+// JustNow 设置显示「刚刚」的时间差上限。
+func (o *humanizeOpts) JustNow(d time.Duration) *humanizeOpts {
+	o.justNow = d
+	return o
+}
+
+// HourLimit 设置按「N小时前」显示的时间差上限（仍要求同一自然日），超过后显示「今天 HH:mm」。
+func (o *humanizeOpts) HourLimit(d time.Duration) *humanizeOpts {
+	o.hourLimit = d
+	return o
+}
+
+// DayLimit 设置按「N天前」显示的自然日差上限（不含），超过后显示日期。
+func (o *humanizeOpts) DayLimit(n int) *humanizeOpts {
+	o.dayLimit = n
+	return o
+}
+
+// Calendar 设置是否对相邻自然日使用「昨天 14:20」「明天 09:00」形式，默认开启。
+func (o *humanizeOpts) Calendar(b bool) *humanizeOpts {
+	o.calendar = b
+	return o
+}
+
+func resolveHumanizeOpts(opts ...*humanizeOpts) *humanizeOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return HumanizeOpts()
+	}
+	return opts[0]
+}
+
+// Humanize 返回 t 相对 now 的友好描述，如「刚刚」「3分钟前」「昨天 14:20」「2 days ago」「in 3 hours」。
+// 按墙上时钟计算，不受夏令时影响。
+func Humanize(t, now types.LocalDateTime, locale Locale, opts ...*humanizeOpts) string {
+	o := resolveHumanizeOpts(opts...)
+	en := locale.isEn()
+	tw, nw := wallUTC(t), wallUTC(now)
+	diff := nw.Sub(tw)
+	past := diff >= 0
+	abs := diff.Abs()
+	days := int(dayStart(nw).Sub(dayStart(tw)) / (24 * time.Hour))
+	absDays := max(days, -days)
+
+	switch {
+	case abs < o.justNow:
+		if en {
+			return "just now"
+		}
+		return "刚刚"
+	case abs < time.Hour:
+		return relativeUnit(int(abs/time.Minute), "分钟", "minute", past, en)
+	case abs < o.hourLimit && days == 0:
+		return relativeUnit(int(abs/time.Hour), "小时", "hour", past, en)
+	case o.calendar && (absDays <= 1 || absDays == 2 && !en):
+		return calendarDay(days, tw.Format("15:04"), en)
+	case absDays == 0:
+		return relativeUnit(int(abs/time.Hour), "小时", "hour", past, en)
+	case absDays < o.dayLimit:
+		return relativeUnit(absDays, "天", "day", past, en)
+	}
+
+	if tw.Year() == nw.Year() {
+		if en {
+			return tw.Format("Jan 2 15:04")
+		}
+		return tw.Format("1月2日 15:04")
+	}
+	if en {
+		return tw.Format("Jan 2, 2006")
+	}
+	return tw.Format("2006年1月2日")
+}
+
+// HumanizeWithClock 同 Humanize，以时钟 c 的当前时间为基准。
+func HumanizeWithClock(t types.LocalDateTime, c clockutil.Clock, locale Locale, opts ...*humanizeOpts) string {
+	return Humanize(t, clockutil.NowDateTime(c), locale, opts...)
+}
+
+func relativeUnit(n int, zh, en string, past, isEn bool) string {
+	if !isEn {
+		if past {
+			return fmt.Sprintf("%d%s前", n, zh)
+		}
+		return fmt.Sprintf("%d%s后", n, zh)
+	}
+	if n != 1 {
+		en += "s"
+	}
+	if past {
+		return fmt.Sprintf("%d %s ago", n, en)
+	}
+	return fmt.Sprintf("in %d %s", n, en)
+}
+
+func calendarDay(days int, clock string, en bool) string {
+	if en {
+		name := map[int]string{1: "yesterday", 0: "today", -1: "tomorrow"}[days]
+		return name + " at " + clock
+	}
+	name := map[int]string{2: "前天", 1: "昨天", 0: "今天", -1: "明天", -2: "后天"}[days]
+	return name + " " + clock
+}
+
+// wallUTC 将墙上时钟映射到 UTC，便于无夏令时干扰地做差与加减。
+func wallUTC(t types.LocalDateTime) time.Time {
+	g := t.ToGoTime()
+	return time.Date(g.Year(), g.Month(), g.Day(), g.Hour(), g.Minute(), g.Second(), 0, time.UTC)
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,48 @@
+package datetimeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHumanize(t *testing.T) {
+	as := assert.New(t)
+	now := mustDT(2025, 3, 12, 16, 0, 0)
+	cases := []struct {
+		t      [6]int
+		zh, en string
+	}{
+		{[6]int{2025, 3, 12, 15, 59, 30}, "刚刚", "just now"},
+		{[6]int{2025, 3, 12, 15, 57, 0}, "3分钟前", "3 minutes ago"},
+		{[6]int{2025, 3, 12, 15, 0, 0}, "1小时前", "1 hour ago"},
+		{[6]int{2025, 3, 12, 16, 30, 0}, "30分钟后", "in 30 minutes"},
+		{[6]int{2025, 3, 12, 2, 0, 0}, "14小时前", "14 hours ago"},
+		{[6]int{2025, 3, 11, 14, 20, 0}, "昨天 14:20", "yesterday at 14:20"},
+		{[6]int{2025, 3, 10, 9, 5, 0}, "前天 09:05", "2 days ago"},
+		{[6]int{2025, 3, 13, 9, 0, 0}, "明天 09:00", "tomorrow at 09:00"},
+		{[6]int{2025, 3, 8, 9, 0, 0}, "4天前", "4 days ago"},
+		{[6]int{2025, 3, 16, 9, 0, 0}, "4天后", "in 4 days"},
+		{[6]int{2025, 1, 5, 8, 30, 0}, "1月5日 08:30", "Jan 5 08:30"},
+		{[6]int{2023, 8, 15, 8, 30, 0}, "2023年8月15日", "Aug 15, 2023"},
+	}
+	for _, c := range cases {
+		v := mustDT(c.t[0], c.t[1], c.t[2], c.t[3], c.t[4], c.t[5])
+		as.Equal(c.zh, Humanize(v, now, LocaleZh), v.String())
+		as.Equal(c.en, Humanize(v, now, "en-US"), v.String())
+	}
+}
+
+func TestHumanize_opts(t *testing.T) {
+	as := assert.New(t)
+	now := mustDT(2025, 3, 12, 16, 0, 0)
+	opts := HumanizeOpts().JustNow(10 * time.Second).HourLimit(3 * time.Hour).DayLimit(3)
+	as.Equal("0分钟前", Humanize(mustDT(2025, 3, 12, 15, 59, 30), now, LocaleZh, opts))
+	as.Equal("今天 09:00", Humanize(mustDT(2025, 3, 12, 9, 0, 0), now, LocaleZh, opts))
+	as.Equal("3月8日 09:00", Humanize(mustDT(2025, 3, 8, 9, 0, 0), now, LocaleZh, opts))
+
+	noCal := HumanizeOpts().Calendar(false)
+	as.Equal("1天前", Humanize(mustDT(2025, 3, 11, 14, 20, 0), now, LocaleZh, noCal))
+	as.Equal("Mar 13 09:00", Humanize(mustDT(2025, 3, 13, 9, 0, 0), now, LocaleEn, HumanizeOpts().Calendar(false).DayLimit(0)), "相差 1 个自然日但 DayLimit 为 0 时显示日期")
+}
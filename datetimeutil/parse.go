@@ -0,0 +1,409 @@
+package datetimeutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+var (
+	// ErrUnrecognizedFormat 无法识别的日期时间格式。
+	ErrUnrecognizedFormat = errors.New("无法识别的日期时间格式")
+	// ErrAmbiguousDate 日期存在多种解释，需通过 ParseAnyOpts 指定。
+	ErrAmbiguousDate = errors.New("日期有歧义")
+)
+
+// DateOrder 「日/月/年」与「月/日/年」的解析顺序。
+type DateOrder int
+
+const (
+	OrderAuto       DateOrder = iota // 仅在无歧义时解析（某一位大于 12 或两者相等），否则返回 ErrAmbiguousDate
+	OrderDayFirst                    // 日在前，如 05/01/2026 为 1 月 5 日
+	OrderMonthFirst                  // 月在前，如 01/05/2026 为 1 月 5 日
+)
+
+// NumericKind 纯数字输入的含义。
+type NumericKind int
+
+const (
+	NumericAuto      NumericKind = iota // 按位数判断：8/12/14 位为紧凑日期，9~10 位为 Unix 秒，13 位为 Unix 毫秒，≤7 位或带小数为 Excel 序列号
+	NumericCompact                      // 紧凑日期 yyyyMMdd[HHmm[ss]]
+	NumericExcel                        // Excel 序列号（可带小数表示时刻）
+	NumericUnix                         // Unix 秒
+	NumericUnixMilli                    // Unix 毫秒
+)
+
+type parseAnyOpts struct {
+	order     DateOrder
+	numeric   NumericKind
+	excel1904 bool
+	loc       *time.Location
+}
+
+// ParseAnyOpts 创建 ParseAny 选项，默认 OrderAuto、NumericAuto、Excel 1900 日期系统，
+// 带时区的输入（RFC 3339、Unix 时间戳）转换到 time.Local。
+func ParseAnyOpts() *parseAnyOpts {
+	return &parseAnyOpts{}
+}
+
+// DayFirst 有歧义时按「日/月/年」解析。
+func (o *parseAnyOpts) DayFirst() *parseAnyOpts {
+	o.order = OrderDayFirst
+	return o
+}
+
+// MonthFirst 有歧义时按「月/日/年」解析。
+func (o *parseAnyOpts) MonthFirst() *parseAnyOpts {
+	o.order = OrderMonthFirst
+	return o
+}
+
+// Numeric 指定纯数字输入的含义。
+func (o *parseAnyOpts) Numeric(k NumericKind) *parseAnyOpts {
+	o.numeric = k
+	return o
+}
+
+// Excel1904 使用 Excel 1904 日期系统（旧版 Mac Excel）解释序列号。
+func (o *parseAnyOpts) Excel1904() *parseAnyOpts {
+	o.excel1904 = true
+	return o
+}
+
+// Location 设置带时区输入转换为本地日期时间所用的时区。
+func (o *parseAnyOpts) Location(loc *time.Location) *parseAnyOpts {
+	o.loc = loc
+	return o
+}
+
+func resolveParseAnyOpts(opts ...*parseAnyOpts) *parseAnyOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return ParseAnyOpts()
+	}
+	return opts[0]
+}
+
+func (o *parseAnyOpts) location() *time.Location {
+	if o.loc != nil {
+		return o.loc
+	}
+	return time.Local
+}
+
+var (
+	numericRe   = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+	ymdRe       = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})[-/.](\d{1,2})(?:[ t]+(.+))?$`)
+	dmyRe       = regexp.MustCompile(`^(\d{1,2})[-/.](\d{1,2})[-/.](\d{4})(?:[ t]+(.+))?$`)
+	ymRe        = regexp.MustCompile(`^(\d{4})[-/.](\d{1,2})$`)
+	monthNameRe = regexp.MustCompile(`^(?:(\d{1,2})[ -]([a-z]{3,9})\.?|([a-z]{3,9})\.?[ -](\d{1,2})(?:st|nd|rd|th)?)[ ,-]+(\d{4})(?:[ t]+(.+))?$`)
+	zhDateRe    = regexp.MustCompile(`^([〇零一二三四五六七八九]{4})年([一二三四五六七八九十]+)月([一二三四五六七八九十]+)[日号]$`)
+	clockRe     = regexp.MustCompile(`^(\d{1,2}):(\d{1,2})(?::(\d{1,2})(?:[.,](\d{1,9}))?)?(?: ?(am|pm))?(?: ?(z|[+-]\d{2}:?\d{2}))?$`)
+)
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// zoneLayouts 带时区或英文星期的标准格式。
+var zoneLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04Z07:00",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// ParseAny 宽松解析常见日期时间格式，适用于 Excel、CSV 等导入数据：
+//   - 2026-01-05、2026/1/5、2026.1.5 15:04、2026年1月5日 15时4分、二〇二六年一月五日
+//   - 05/01/2026、1/5/2026：两位均不大于 12 时需通过 DayFirst / MonthFirst 指定，否则返回 ErrAmbiguousDate
+//   - Jan 5, 2026、5 January 2026
+//   - 20260105、202601051504、20260105150405、Unix 秒/毫秒、Excel 序列号（含义见 NumericKind）
+//   - RFC 3339 / RFC 1123 等带时区格式，转换到 Location 指定的时区
+//
+// 全角数字与符号会先转换为半角；仅有日期时时刻为 00:00:00，秒以下精度被舍弃。
+// 结果落在 time.Local 夏令时跳过的时段内时无法用 types.LocalDateTime 表示，返回 ErrNonexistentTime。
+func ParseAny(s string, opts ...*parseAnyOpts) (types.LocalDateTime, error) {
+	o := resolveParseAnyOpts(opts...)
+	src := s
+	s = normalizeDateText(s)
+	if s == "" {
+		return types.LocalDateTime{}, fmt.Errorf("%w: 空字符串", ErrUnrecognizedFormat)
+	}
+	t, err := parseAny(s, o)
+	if err != nil {
+		return types.LocalDateTime{}, fmt.Errorf("解析 %q 失败: %w", src, err)
+	}
+	dt, err := localDateTimeOf(t)
+	if err != nil {
+		return types.LocalDateTime{}, fmt.Errorf("解析 %q 失败: %w", src, err)
+	}
+	return dt, nil
+}
+
+// ParseAnyDate 同 ParseAny，仅返回日期部分。
+func ParseAnyDate(s string, opts ...*parseAnyOpts) (types.LocalDate, error) {
+	dt, err := ParseAny(s, opts...)
+	if err != nil {
+		return types.LocalDate{}, err
+	}
+	return dt.ToDate(), nil
+}
+
+func parseAny(s string, o *parseAnyOpts) (time.Time, error) {
+	if numericRe.MatchString(s) {
+		return parseNumeric(s, o)
+	}
+	if m := zhDateRe.FindStringSubmatch(s); m != nil {
+		return parseZhDate(m)
+	}
+	for _, layout := range zoneLayouts {
+		// 月份、星期名称不区分大小写，字面量 T、Z 区分，故额外尝试全大写
+		for _, v := range []string{s, strings.ToUpper(s)} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t.In(o.location()), nil
+			}
+		}
+	}
+	s = strings.ToLower(s)
+	if m := ymdRe.FindStringSubmatch(s); m != nil {
+		return buildDateTime(atoi(m[1]), atoi(m[2]), atoi(m[3]), m[4], o)
+	}
+	if m := dmyRe.FindStringSubmatch(s); m != nil {
+		a, b := atoi(m[1]), atoi(m[2])
+		day, month, err := resolveOrder(a, b, o.order)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return buildDateTime(atoi(m[3]), month, day, m[4], o)
+	}
+	if m := ymRe.FindStringSubmatch(s); m != nil {
+		return buildDateTime(atoi(m[1]), atoi(m[2]), 1, "", o)
+	}
+	if m := monthNameRe.FindStringSubmatch(s); m != nil {
+		day, name := m[1], m[2]
+		if name == "" {
+			day, name = m[4], m[3]
+		}
+		month, ok := monthNames[name[:3]]
+		if !ok || !strings.HasPrefix(fullMonthName(month), name) {
+			return time.Time{}, fmt.Errorf("%w: 未知月份 %q", ErrUnrecognizedFormat, name)
+		}
+		return buildDateTime(atoi(m[5]), month, atoi(day), m[6], o)
+	}
+	return time.Time{}, ErrUnrecognizedFormat
+}
+
+// resolveOrder 解析「a/b/年」中的日与月。
+func resolveOrder(a, b int, order DateOrder) (day, month int, err error) {
+	switch {
+	case a > 12 && b > 12:
+		return 0, 0, fmt.Errorf("%w: %d 与 %d 均大于 12", ErrUnrecognizedFormat, a, b)
+	case a > 12:
+		return a, b, nil
+	case b > 12, a == b:
+		return b, a, nil
+	}
+	switch order {
+	case OrderDayFirst:
+		return a, b, nil
+	case OrderMonthFirst:
+		return b, a, nil
+	}
+	return 0, 0, fmt.Errorf("%w: 可解释为 %d 月 %d 日（月在前）或 %d 月 %d 日（日在前），请使用 ParseAnyOpts().DayFirst() 或 MonthFirst()",
+		ErrAmbiguousDate, a, b, b, a)
+}
+
+// buildDateTime 组合日期与可选的时刻部分；时刻带时区时转换到 o.location()。
+func buildDateTime(y, m, d int, clock string, o *parseAnyOpts) (time.Time, error) {
+	if err := checkDate(y, m, d); err != nil {
+		return time.Time{}, err
+	}
+	if clock == "" {
+		return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC), nil
+	}
+	c := clockRe.FindStringSubmatch(clock)
+	if c == nil {
+		return time.Time{}, fmt.Errorf("%w: 时刻 %q", ErrUnrecognizedFormat, clock)
+	}
+	h, mi, sec := atoi(c[1]), atoi(c[2]), atoi(c[3])
+	if c[5] != "" {
+		if h < 1 || h > 12 {
+			return time.Time{}, fmt.Errorf("%w: 12 小时制小时数 %d", ErrUnrecognizedFormat, h)
+		}
+		h %= 12
+		if c[5] == "pm" {
+			h += 12
+		}
+	}
+	if h > 23 || mi > 59 || sec > 59 {
+		return time.Time{}, fmt.Errorf("%w: 非法时刻 %q", ErrUnrecognizedFormat, clock)
+	}
+	if c[6] == "" {
+		return time.Date(y, time.Month(m), d, h, mi, sec, 0, time.UTC), nil
+	}
+	offset := 0
+	if c[6] != "z" {
+		z := strings.ReplaceAll(c[6][1:], ":", "")
+		offset = (atoi(z[:2])*60 + atoi(z[2:])) * 60
+		if c[6][0] == '-' {
+			offset = -offset
+		}
+	}
+	return time.Date(y, time.Month(m), d, h, mi, sec, 0, time.FixedZone("", offset)).In(o.location()), nil
+}
+
+func parseNumeric(s string, o *parseAnyOpts) (time.Time, error) {
+	kind := o.numeric
+	intPart, _, hasFrac := strings.Cut(s, ".")
+	if kind == NumericAuto {
+		switch n := len(intPart); {
+		case hasFrac || n <= 7:
+			kind = NumericExcel
+		case n == 8 || n == 12 || n == 14:
+			kind = NumericCompact
+		case n == 9 || n == 10:
+			kind = NumericUnix
+		case n == 13:
+			kind = NumericUnixMilli
+		default:
+			return time.Time{}, fmt.Errorf("%w: %d 位数字无法判断含义，请使用 ParseAnyOpts().Numeric() 指定", ErrAmbiguousDate, n)
+		}
+	}
+	if hasFrac && kind != NumericExcel {
+		return time.Time{}, fmt.Errorf("%w: 仅 Excel 序列号可带小数", ErrUnrecognizedFormat)
+	}
+
+	switch kind {
+	case NumericCompact:
+		if n := len(s); n != 8 && n != 12 && n != 14 {
+			return time.Time{}, fmt.Errorf("%w: 紧凑日期应为 8、12 或 14 位", ErrUnrecognizedFormat)
+		}
+		clock := ""
+		if len(s) > 8 {
+			clock = s[8:10] + ":" + s[10:12]
+			if len(s) == 14 {
+				clock += ":" + s[12:14]
+			}
+		}
+		return buildDateTime(atoi(s[:4]), atoi(s[4:6]), atoi(s[6:8]), clock, o)
+	case NumericUnix, NumericUnixMilli:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %v", ErrUnrecognizedFormat, err)
+		}
+		if kind == NumericUnix {
+			return time.Unix(n, 0).In(o.location()), nil
+		}
+		return time.UnixMilli(n).In(o.location()), nil
+	}
+	return excelSerial(s, o.excel1904)
+}
+
+// excelSerial 将 Excel 序列号转换为日期时间。1900 日期系统沿用 Excel 将 1900 年视为闰年的错误，
+// 序列号 60（不存在的 1900-02-29）返回错误。
+func excelSerial(s string, is1904 bool) (time.Time, error) {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 1 || v >= 2958466 {
+		return time.Time{}, fmt.Errorf("%w: Excel 序列号超出范围 %s", ErrUnrecognizedFormat, s)
+	}
+	days := math.Floor(v)
+	base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	switch {
+	case is1904:
+		base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	case days == 60:
+		return time.Time{}, fmt.Errorf("%w: Excel 序列号 60 对应不存在的 1900-02-29", ErrUnrecognizedFormat)
+	case days < 60:
+		base = base.AddDate(0, 0, 1)
+	}
+	secs := math.Round((v - days) * 86400)
+	return base.AddDate(0, 0, int(days)).Add(time.Duration(secs) * time.Second), nil
+}
+
+func parseZhDate(m []string) (time.Time, error) {
+	year := 0
+	for _, r := range m[1] {
+		d, _ := parseZhNumber(string(r))
+		year = year*10 + d
+	}
+	month, _ := parseZhNumber(m[2])
+	day, _ := parseZhNumber(m[3])
+	if err := checkDate(year, month, day); err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+func checkDate(y, m, d int) error {
+	if m < 1 || m > 12 || d < 1 || d > time.Date(y, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day() || y < 1 {
+		return fmt.Errorf("%w: 非法日期 %04d-%02d-%02d", ErrUnrecognizedFormat, y, m, d)
+	}
+	return nil
+}
+
+// normalizeDateText 全角转半角，并将中文年月日时分秒、上午下午转换为通用分隔符。
+func normalizeDateText(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '！' && r <= '～':
+			r -= '！' - '!'
+		case r == '　':
+			r = ' '
+		}
+		sb.WriteRune(r)
+	}
+	s = strings.TrimSpace(sb.String())
+	if zhDateRe.MatchString(s) {
+		return s
+	}
+
+	suffix := ""
+	for prefix, ampm := range map[string]string{"上午": "am", "早上": "am", "凌晨": "am", "下午": "pm", "晚上": "pm", "中午": "pm"} {
+		if i := strings.Index(s, prefix); i >= 0 {
+			s = s[:i] + " " + s[i+len(prefix):]
+			suffix = ampm
+		}
+	}
+	s = strings.NewReplacer(
+		"年", "-", "月", "-", "日", " ", "号", " ",
+		"时", ":", "点", ":", "分", ":", "秒", "",
+	).Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.TrimRight(s, ":- ")
+	// 「2026-1-5 15点」仅有小时时补齐分钟
+	if i := strings.LastIndex(s, " "); i > 0 && isDigits(s[i+1:]) && len(s)-i-1 <= 2 {
+		s += ":00"
+	}
+	if suffix != "" {
+		s += " " + suffix
+	}
+	return s
+}
+
+func fullMonthName(m int) string {
+	return strings.ToLower(time.Month(m).String())
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,116 @@
+package datetimeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAny(t *testing.T) {
+	as := assert.New(t)
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	opts := ParseAnyOpts().Location(shanghai)
+
+	cases := map[string][6]int{
+		"2026-01-05":                    {2026, 1, 5, 0, 0, 0},
+		"2026/1/5":                      {2026, 1, 5, 0, 0, 0},
+		"2026.1.5 8:05":                 {2026, 1, 5, 8, 5, 0},
+		"2026-01-05T15:04:05":           {2026, 1, 5, 15, 4, 5},
+		"2026-01-05 15:04:05.123":       {2026, 1, 5, 15, 4, 5},
+		"2026年1月5日":                     {2026, 1, 5, 0, 0, 0},
+		"２０２６年１月５日":                     {2026, 1, 5, 0, 0, 0},
+		"2026年1月5日 15时4分30秒":            {2026, 1, 5, 15, 4, 30},
+		"2026年1月5日下午3点":                 {2026, 1, 5, 15, 0, 0},
+		"二〇二六年一月二十五日":                   {2026, 1, 25, 0, 0, 0},
+		"2026年1月":                       {2026, 1, 1, 0, 0, 0},
+		"20260105":                      {2026, 1, 5, 0, 0, 0},
+		"20260105150405":                {2026, 1, 5, 15, 4, 5},
+		"46027":                         {2026, 1, 5, 0, 0, 0},
+		"46027.75":                      {2026, 1, 5, 18, 0, 0},
+		"1767542400":                    {2026, 1, 5, 0, 0, 0},
+		"1767542400000":                 {2026, 1, 5, 0, 0, 0},
+		"2026-01-04T16:00:00Z":          {2026, 1, 5, 0, 0, 0},
+		"2026-01-05T09:30:00+09:00":     {2026, 1, 5, 8, 30, 0},
+		"2026-01-05 00:00:00 +0000":     {2026, 1, 5, 8, 0, 0},
+		"Mon, 05 Jan 2026 00:00:00 GMT": {2026, 1, 5, 8, 0, 0},
+		"Jan 5, 2026":                   {2026, 1, 5, 0, 0, 0},
+		"5 January 2026 3:04 PM":        {2026, 1, 5, 15, 4, 0},
+		"25/12/2026":                    {2026, 12, 25, 0, 0, 0},
+		"12/25/2026 23:59":              {2026, 12, 25, 23, 59, 0},
+		"5/5/2026":                      {2026, 5, 5, 0, 0, 0},
+	}
+	for s, want := range cases {
+		got, err := ParseAny(s, opts)
+		if as.NoError(err, s) {
+			as.Equal(mustDT(want[0], want[1], want[2], want[3], want[4], want[5]), got, s)
+		}
+	}
+}
+
+func TestParseAny_order(t *testing.T) {
+	as := assert.New(t)
+	_, err := ParseAny("01/05/2026")
+	as.ErrorIs(err, ErrAmbiguousDate)
+	as.Contains(err.Error(), "DayFirst")
+
+	got, err := ParseAnyDate("01/05/2026", ParseAnyOpts().DayFirst())
+	as.NoError(err)
+	as.Equal("2026-05-01", got.String())
+	got, err = ParseAnyDate("01/05/2026", ParseAnyOpts().MonthFirst())
+	as.NoError(err)
+	as.Equal("2026-01-05", got.String())
+}
+
+func TestParseAny_numeric(t *testing.T) {
+	as := assert.New(t)
+	utc := ParseAnyOpts().Location(time.UTC)
+
+	_, err := ParseAny("12345678901")
+	as.ErrorIs(err, ErrAmbiguousDate)
+
+	_, err = ParseAny("20260105", ParseAnyOpts().Numeric(NumericExcel))
+	as.Error(err, "超出 Excel 序列号范围")
+
+	got, err := ParseAnyDate("1", utc)
+	as.NoError(err)
+	as.Equal("1900-01-01", got.String())
+	got, err = ParseAnyDate("61", utc)
+	as.NoError(err)
+	as.Equal("1900-03-01", got.String())
+	_, err = ParseAny("60")
+	as.Error(err)
+
+	got, err = ParseAnyDate("44566", ParseAnyOpts().Excel1904())
+	as.NoError(err)
+	as.Equal("2026-01-06", got.String())
+
+	dt, err := ParseAny("1700000000", ParseAnyOpts().Numeric(NumericUnix).Location(time.UTC))
+	as.NoError(err)
+	as.Equal(mustDT(2023, 11, 14, 22, 13, 20), dt)
+}
+
+func TestParseAny_invalid(t *testing.T) {
+	as := assert.New(t)
+	for _, s := range []string{"", "hello", "2026-02-30", "2026-13-01", "13/13/2026", "2026-01-05 25:00", "Foo 5, 2026", "1/13/2026 13pm"} {
+		_, err := ParseAny(s)
+		as.ErrorIs(err, ErrUnrecognizedFormat, s)
+	}
+}
+
+func TestParseAny_localGap(t *testing.T) {
+	as := assert.New(t)
+	// 纽约 2025-03-09 02:30 不存在，无法用 types.LocalDateTime 表示
+	setLocal(t, "America/New_York")
+	for _, s := range []string{"2025-03-09 02:30", "2025/3/9 2:30:15", "2025年3月9日 2时30分"} {
+		_, err := ParseAny(s)
+		as.ErrorIs(err, ErrNonexistentTime, s)
+	}
+	_, err := ParseAny("2025-03-09T02:30:00Z", ParseAnyOpts().Location(time.UTC))
+	as.ErrorIs(err, ErrNonexistentTime)
+	got, err := ParseAny("2025-03-09 03:30")
+	as.NoError(err)
+	as.Equal(mustDT(2025, 3, 9, 3, 30, 0), got)
+}
@@ -0,0 +1,149 @@
+package datetimeutil
+
+import (
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/dateutil"
+)
+
+type periodOpts struct {
+	weekStart time.Weekday
+	loc       *time.Location
+}
+
+// PeriodOpts 创建周期选项，默认一周从周一开始；LocalDateTime 默认按 time.Local 解释，
+// time.Time 默认使用其自身时区。
+func PeriodOpts() *periodOpts {
+	return &periodOpts{weekStart: time.Monday}
+}
+
+// WeekStart 设置一周的起始日。
+func (o *periodOpts) WeekStart(d time.Weekday) *periodOpts {
+	o.weekStart = d
+	return o
+}
+
+// Location 设置计算周期边界所用的时区。
+// 周期起点落在夏令时跳过的时段内（如某些时区零点不存在）时，取跳变后的第一个时刻。
+func (o *periodOpts) Location(loc *time.Location) *periodOpts {
+	o.loc = loc
+	return o
+}
+
+func resolvePeriodOpts(opts ...*periodOpts) *periodOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return PeriodOpts()
+	}
+	return opts[0]
+}
+
+// StartOf 返回 t 所在周期的起始时刻，如 StartOf(t, dateutil.PeriodMonth) 为本月 1 日 00:00:00。
+func StartOf(t types.LocalDateTime, unit dateutil.PeriodUnit, opts ...*periodOpts) types.LocalDateTime {
+	o := resolvePeriodOpts(opts...)
+	loc := o.location(time.Local)
+	return types.LocalDateTimeOf(periodStart(dayStart(wallUTC(t)), unit, o, loc))
+}
+
+// EndOf 返回 t 所在周期的最后一秒，如 EndOf(t, dateutil.PeriodDay) 为当天 23:59:59。
+func EndOf(t types.LocalDateTime, unit dateutil.PeriodUnit, opts ...*periodOpts) types.LocalDateTime {
+	o := resolvePeriodOpts(opts...)
+	loc := o.location(time.Local)
+	return types.LocalDateTimeOf(periodEnd(dayStart(wallUTC(t)), unit, o, loc).Add(-time.Second))
+}
+
+// StartOfTime 返回时刻 t 在指定时区（默认 t.Location()）下所在周期的起始时刻。
+func StartOfTime(t time.Time, unit dateutil.PeriodUnit, opts ...*periodOpts) time.Time {
+	o := resolvePeriodOpts(opts...)
+	loc := o.location(t.Location())
+	return periodStart(dayStart(wallClock(t.In(loc))), unit, o, loc)
+}
+
+// EndOfTime 返回时刻 t 在指定时区（默认 t.Location()）下所在周期的最后一纳秒。
+func EndOfTime(t time.Time, unit dateutil.PeriodUnit, opts ...*periodOpts) time.Time {
+	o := resolvePeriodOpts(opts...)
+	loc := o.location(t.Location())
+	return periodEnd(dayStart(wallClock(t.In(loc))), unit, o, loc).Add(-time.Nanosecond)
+}
+
+// AddMonthsClamped 加减 n 个月并保留时分秒，目标月没有对应日时取月末。
+// 结果落在 time.Local 夏令时跳过的时段内时按 DSTCompatible 顺延，如 02:30 → 03:30。
+func AddMonthsClamped(t types.LocalDateTime, n int) types.LocalDateTime {
+	w := addMonthsClamped(wallUTC(t), n)
+	u, _ := resolveWallIn(w, time.Local, DSTCompatible)
+	return types.LocalDateTimeOf(wallClock(u))
+}
+
+func (o *periodOpts) location(def *time.Location) *time.Location {
+	if o.loc != nil {
+		return o.loc
+	}
+	return def
+}
+
+// addMonthsClamped 对 UTC 墙上时钟加减 n 个月，目标月没有对应日时取月末。
+func addMonthsClamped(w time.Time, n int) time.Time {
+	y, m, d := w.Date()
+	h, mi, s := w.Clock()
+	last := time.Date(y, m+time.Month(n)+1, 0, 0, 0, 0, 0, time.UTC)
+	return time.Date(last.Year(), last.Month(), min(d, last.Day()), h, mi, s, 0, time.UTC)
+}
+
+// periodFirstDay 返回日期 day（UTC 零点）所在周期的第一天。
+// 不经过 types.LocalDate，以免该日零点在 time.Local 中因夏令时不存在而 panic。
+func periodFirstDay(day time.Time, unit dateutil.PeriodUnit, weekStart time.Weekday) time.Time {
+	y, m, _ := day.Date()
+	switch unit {
+	case dateutil.PeriodDay:
+		return day
+	case dateutil.PeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())-int(weekStart)+7)%7)
+	case dateutil.PeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case dateutil.PeriodQuarter:
+		return time.Date(y, (m-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case dateutil.PeriodHalfYear:
+		return time.Date(y, (m-1)/6*6+1, 1, 0, 0, 0, 0, time.UTC)
+	case dateutil.PeriodYear:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	panic("datetimeutil: unknown period unit")
+}
+
+// periodStart 返回 day 所在周期第一天在 loc 中的零点。
+func periodStart(day time.Time, unit dateutil.PeriodUnit, o *periodOpts, loc *time.Location) time.Time {
+	return midnight(periodFirstDay(day, unit, o.weekStart), loc)
+}
+
+// periodEnd 返回 day 所在周期结束后（下一周期第一天）在 loc 中的零点。
+func periodEnd(day time.Time, unit dateutil.PeriodUnit, o *periodOpts, loc *time.Location) time.Time {
+	first := periodFirstDay(day, unit, o.weekStart)
+	var next time.Time
+	switch unit {
+	case dateutil.PeriodDay:
+		next = first.AddDate(0, 0, 1)
+	case dateutil.PeriodWeek:
+		next = first.AddDate(0, 0, 7)
+	case dateutil.PeriodMonth:
+		next = first.AddDate(0, 1, 0)
+	case dateutil.PeriodQuarter:
+		next = first.AddDate(0, 3, 0)
+	case dateutil.PeriodHalfYear:
+		next = first.AddDate(0, 6, 0)
+	default:
+		next = first.AddDate(1, 0, 0)
+	}
+	return midnight(next, loc)
+}
+
+// midnight 返回日期 day（UTC 零点）在 loc 中的零点；零点因夏令时不存在时取跳变后的第一个时刻。
+func midnight(day time.Time, loc *time.Location) time.Time {
+	y, m, d := day.Date()
+	t := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	if t.Day() != d {
+		// time.Date 在跳变时段可能回退到前一天，向后取跳变时刻
+		_, end := t.ZoneBounds()
+		return end
+	}
+	return t
+}
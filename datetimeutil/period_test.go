@@ -0,0 +1,68 @@
+package datetimeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lontten/lutil/dateutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartOfEndOf(t *testing.T) {
+	as := assert.New(t)
+	v := mustDT(2024, 8, 15, 13, 45, 10)
+	as.Equal(mustDT(2024, 8, 15, 0, 0, 0), StartOf(v, dateutil.PeriodDay))
+	as.Equal(mustDT(2024, 8, 15, 23, 59, 59), EndOf(v, dateutil.PeriodDay))
+	as.Equal(mustDT(2024, 8, 12, 0, 0, 0), StartOf(v, dateutil.PeriodWeek))
+	as.Equal(mustDT(2024, 8, 17, 23, 59, 59), EndOf(v, dateutil.PeriodWeek, PeriodOpts().WeekStart(time.Sunday)))
+	as.Equal(mustDT(2024, 7, 1, 0, 0, 0), StartOf(v, dateutil.PeriodQuarter))
+	as.Equal(mustDT(2024, 12, 31, 23, 59, 59), EndOf(v, dateutil.PeriodYear))
+}
+
+func TestStartOfTime(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	req.NoError(err)
+
+	// UTC 2024-03-31 20:00 为上海 04-01 04:00，按上海时区属于第二季度
+	v := time.Date(2024, 3, 31, 20, 0, 0, 0, time.UTC)
+	as.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, shanghai), StartOfTime(v, dateutil.PeriodQuarter, PeriodOpts().Location(shanghai)))
+	as.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), StartOfTime(v, dateutil.PeriodQuarter))
+	as.Equal(time.Date(2024, 3, 31, 23, 59, 59, 999999999, time.UTC), EndOfTime(v, dateutil.PeriodMonth))
+
+	// 圣保罗 2018-11-04 零点因夏令时不存在，当天从 01:00 开始
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	req.NoError(err)
+	day := time.Date(2018, 11, 4, 12, 0, 0, 0, sp)
+	start := StartOfTime(day, dateutil.PeriodDay)
+	as.Equal(1, start.Hour())
+	as.Equal(4, start.Day())
+	as.Equal(23*time.Hour-time.Nanosecond, EndOfTime(day, dateutil.PeriodDay).Sub(start))
+	as.Equal(mustDT(2018, 11, 4, 1, 0, 0), StartOf(mustDT(2018, 11, 4, 12, 0, 0), dateutil.PeriodDay, PeriodOpts().Location(sp)))
+}
+
+func TestStartOfTime_localGap(t *testing.T) {
+	as := assert.New(t)
+	// time.Local 中 2018-11-04 零点不存在，types.LocalDate 无法表示这一天，计算不能经过它
+	sp := setLocal(t, "America/Sao_Paulo")
+	day := time.Date(2018, 11, 4, 12, 0, 0, 0, sp)
+	as.Equal(time.Date(2018, 11, 4, 1, 0, 0, 0, sp), StartOfTime(day, dateutil.PeriodDay))
+	as.Equal(time.Date(2018, 11, 5, 0, 0, 0, 0, sp).Add(-time.Nanosecond), EndOfTime(day, dateutil.PeriodDay))
+	as.Equal(time.Date(2018, 11, 1, 0, 0, 0, 0, sp), StartOfTime(day, dateutil.PeriodMonth))
+	as.Equal(time.Date(2018, 11, 4, 1, 0, 0, 0, sp), StartOfTime(time.Date(2018, 11, 7, 0, 0, 0, 0, sp), dateutil.PeriodWeek, PeriodOpts().WeekStart(time.Sunday)))
+	as.Equal(mustDT(2018, 11, 5, 8, 0, 0), AddMonthsClamped(mustDT(2018, 10, 5, 8, 0, 0), 1))
+	as.Equal(mustDT(2018, 11, 4, 1, 0, 0), AddMonthsClamped(mustDT(2018, 10, 4, 0, 0, 0), 1))
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	as := assert.New(t)
+	as.Equal(mustDT(2024, 2, 29, 8, 30, 0), AddMonthsClamped(mustDT(2024, 1, 31, 8, 30, 0), 1))
+	as.Equal(mustDT(2023, 12, 31, 23, 59, 59), AddMonthsClamped(mustDT(2024, 3, 31, 23, 59, 59), -3))
+	as.Equal(mustDT(2024, 3, 31, 0, 0, 0), AddMonthsClamped(mustDT(2025, 1, 31, 0, 0, 0), -10))
+
+	// 纽约 2025-03-09 02:30 不存在，按 DSTCompatible 顺延到 03:30
+	setLocal(t, "America/New_York")
+	as.Equal(mustDT(2025, 3, 9, 3, 30, 0), AddMonthsClamped(mustDT(2025, 2, 9, 2, 30, 0), 1))
+}
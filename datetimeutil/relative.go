@@ -0,0 +1,350 @@
+package datetimeutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
+)
+
+// ErrInvalidRelative 无法识别的相对时间表达式。
+var ErrInvalidRelative = errors.New("无法识别的相对时间")
+
+var (
+	zhOffsetRe = regexp.MustCompile(`^(\d+|[零〇一二两三四五六七八九十百]+)?(?:个)?(半)?(?:个)?(秒钟?|分钟?|小时|钟头|天|日|周|星期|礼拜|月|年)(?:以|之)?(前|后)$`)
+	zhDayRe    = regexp.MustCompile(`^(大前天|前天|昨天|今天|明天|后天|大后天)(.*)$`)
+	zhWeekRe   = regexp.MustCompile(`^(上上|上|本|这|下下|下)?(?:个)?(?:周|星期|礼拜)([一二三四五六日天1-7])(.*)$`)
+	zhUnitRe   = regexp.MustCompile(`^(上|下)(?:个)?(周|星期|月)$`)
+	zhClockRe  = regexp.MustCompile(`^(早上|上午|中午|下午|晚上|凌晨)?(\d{1,2}|[零一二两三四五六七八九十]+)(?:[:：](\d{2})(?:[:：](\d{2}))?|点(?:(\d{1,2}|[零一二三四五六七八九十]+)分?|(半))?)?$`)
+
+	enOffsetRe = regexp.MustCompile(`^(?:in )?(\d+|an?|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve) (second|sec|minute|min|hour|day|week|month|year)s?(?: (ago|later|from now))?$`)
+	enDayRe    = regexp.MustCompile(`^(today|tomorrow|yesterday|tonight)(?: at (.+))?$`)
+	enWeekRe   = regexp.MustCompile(`^(?:(next|last|this) )?(monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tues|tue|wed|thurs|thu|fri|sat|sun)(?: at (.+))?$`)
+	enUnitRe   = regexp.MustCompile(`^(next|last) (week|month|year)$`)
+	enClockRe  = regexp.MustCompile(`^(\d{1,2})(?::(\d{2})(?::(\d{2}))?)? ?(am|pm)?$`)
+)
+
+var enNumbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+var enWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseRelative 以 now 为基准解析相对时间，支持中英文：
+//   - 偏移量：「3天后」「两小时前」「半小时后」「3个月前」「in 2 hours」「3 days ago」
+//   - 自然日：「昨天」「明天 14:20」「后天下午3点」「tomorrow at 9am」
+//   - 星期：「下周一」「上周五 10:00」「周日」「next monday」「last friday」「this sunday」
+//   - 周期：「下个月」「上周」「next month」「last year」
+//   - 「刚刚」「现在」「now」「just now」
+//
+// 偏移量与周期保留 now 的时分秒；自然日、星期未指定时刻时取当天 00:00:00。
+// 月、年偏移遇月末取当月最后一天。结果落在 time.Local 夏令时跳过的时段内时返回 ErrNonexistentTime。中文「下周一」指下一个自然周的周一，
+// 英文 next monday 指今天之后的第一个周一，last monday 指今天之前的最近一个周一。
+func ParseRelative(s string, now types.LocalDateTime) (types.LocalDateTime, error) {
+	src := s
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	base := wallUTC(now)
+	t, ok := parseRelativeZh(strings.ReplaceAll(s, " ", ""), base)
+	if !ok {
+		t, ok = parseRelativeEn(s, base)
+	}
+	if !ok {
+		return types.LocalDateTime{}, fmt.Errorf("%w: %q", ErrInvalidRelative, src)
+	}
+	return localDateTimeOf(t)
+}
+
+// ParseRelativeWithClock 同 ParseRelative，以时钟 c 的当前时间为基准。
+func ParseRelativeWithClock(s string, c clockutil.Clock) (types.LocalDateTime, error) {
+	return ParseRelative(s, clockutil.NowDateTime(c))
+}
+
+func parseRelativeZh(s string, now time.Time) (time.Time, bool) {
+	switch s {
+	case "刚刚", "现在", "此刻":
+		return now, true
+	}
+	if m := zhOffsetRe.FindStringSubmatch(s); m != nil {
+		if m[1] == "" && m[2] == "" {
+			return time.Time{}, false
+		}
+		n := 0
+		if m[1] != "" {
+			var ok bool
+			if n, ok = parseZhNumber(m[1]); !ok {
+				return time.Time{}, false
+			}
+		}
+		sign := 1
+		if m[4] == "前" {
+			sign = -1
+		}
+		return offsetBy(now, n, m[2] != "", zhUnit(m[3]), sign)
+	}
+	if m := zhDayRe.FindStringSubmatch(s); m != nil {
+		days := map[string]int{"大前天": -3, "前天": -2, "昨天": -1, "今天": 0, "明天": 1, "后天": 2, "大后天": 3}[m[1]]
+		return atClock(dayStart(now).AddDate(0, 0, days), m[2], parseZhClock)
+	}
+	if m := zhWeekRe.FindStringSubmatch(s); m != nil {
+		weeks := map[string]int{"上上": -2, "上": -1, "": 0, "本": 0, "这": 0, "下": 1, "下下": 2}[m[1]]
+		wd := strings.Index("日一二三四五六", m[2]) / len("一")
+		switch m[2] {
+		case "天", "7":
+			wd = 0
+		case "1", "2", "3", "4", "5", "6":
+			wd = int(m[2][0] - '0')
+		}
+		// 以周一为一周起点
+		monday := dayStart(now).AddDate(0, 0, -((int(now.Weekday())+6)%7)+7*weeks)
+		return atClock(monday.AddDate(0, 0, (wd+6)%7), m[3], parseZhClock)
+	}
+	if m := zhUnitRe.FindStringSubmatch(s); m != nil {
+		sign := 1
+		if m[1] == "上" {
+			sign = -1
+		}
+		return offsetBy(now, 1, false, zhUnit(m[2]), sign)
+	}
+	switch s {
+	case "去年", "明年", "前年", "后年":
+		years := map[string]int{"前年": -2, "去年": -1, "明年": 1, "后年": 2}[s]
+		return offsetBy(now, years, false, "year", 1)
+	}
+	return time.Time{}, false
+}
+
+func parseRelativeEn(s string, now time.Time) (time.Time, bool) {
+	switch s {
+	case "now", "just now", "right now":
+		return now, true
+	}
+	if m := enOffsetRe.FindStringSubmatch(s); m != nil {
+		in := strings.HasPrefix(s, "in ")
+		if in == (m[3] != "") {
+			// 必须是 "in 3 days" 或 "3 days ago/later/from now" 之一
+			return time.Time{}, false
+		}
+		n, ok := enNumbers[m[1]]
+		if !ok {
+			n, _ = strconv.Atoi(m[1])
+		}
+		unit := map[string]string{"sec": "second", "min": "minute"}[m[2]]
+		if unit == "" {
+			unit = m[2]
+		}
+		sign := 1
+		if m[3] == "ago" {
+			sign = -1
+		}
+		return offsetBy(now, n, false, unit, sign)
+	}
+	if m := enDayRe.FindStringSubmatch(s); m != nil {
+		if m[1] == "tonight" {
+			clock := m[2]
+			if clock == "" {
+				clock = "8pm"
+			}
+			return atClock(dayStart(now), clock, parseEnClock)
+		}
+		days := map[string]int{"yesterday": -1, "today": 0, "tomorrow": 1}[m[1]]
+		return atClock(dayStart(now).AddDate(0, 0, days), m[2], parseEnClock)
+	}
+	if m := enWeekRe.FindStringSubmatch(s); m != nil {
+		wd := enWeekdays[m[2][:3]]
+		today := dayStart(now)
+		var d time.Time
+		switch m[1] {
+		case "next":
+			d = today.AddDate(0, 0, (int(wd)-int(today.Weekday())+6)%7+1)
+		case "last":
+			d = today.AddDate(0, 0, -((int(today.Weekday())-int(wd)+6)%7 + 1))
+		case "this":
+			// 本周（周一起）对应的星期
+			monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+			d = monday.AddDate(0, 0, (int(wd)+6)%7)
+		default:
+			// 单独的星期名：今天或之后最近的一天
+			d = today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7)
+		}
+		return atClock(d, m[3], parseEnClock)
+	}
+	if m := enUnitRe.FindStringSubmatch(s); m != nil {
+		sign := 1
+		if m[1] == "last" {
+			sign = -1
+		}
+		return offsetBy(now, 1, false, m[2], sign)
+	}
+	return time.Time{}, false
+}
+
+// zhUnit 将中文单位映射为统一的英文单位名。
+func zhUnit(u string) string {
+	switch u {
+	case "秒", "秒钟":
+		return "second"
+	case "分", "分钟":
+		return "minute"
+	case "小时", "钟头":
+		return "hour"
+	case "天", "日":
+		return "day"
+	case "周", "星期", "礼拜":
+		return "week"
+	case "月":
+		return "month"
+	}
+	return "year"
+}
+
+// offsetBy 在 now 上偏移 sign·n 个 unit，half 表示再加半个单位（半月按 15 天，半年按 6 个月）。
+func offsetBy(now time.Time, n int, half bool, unit string, sign int) (time.Time, bool) {
+	switch unit {
+	case "month", "year":
+		months := n
+		if unit == "year" {
+			months *= 12
+			if half {
+				months += 6
+			}
+		}
+		t := addMonthsClamped(now, sign*months)
+		if unit == "month" && half {
+			t = t.AddDate(0, 0, sign*15)
+		}
+		return t, true
+	}
+	step := map[string]time.Duration{
+		"second": time.Second, "minute": time.Minute, "hour": time.Hour,
+		"day": 24 * time.Hour, "week": 7 * 24 * time.Hour,
+	}[unit]
+	d := time.Duration(n) * step
+	if half {
+		d += step / 2
+	}
+	return now.Add(time.Duration(sign) * d), true
+}
+
+// atClock 在 day（当天零点）上设置 clock 描述的时刻；clock 为空时保持零点。
+func atClock(day time.Time, clock string, parse func(string) (int, int, int, bool)) (time.Time, bool) {
+	clock = strings.TrimSpace(clock)
+	if clock == "" {
+		return day, true
+	}
+	h, m, s, ok := parse(clock)
+	if !ok {
+		return time.Time{}, false
+	}
+	return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second), true
+}
+
+func parseZhClock(s string) (int, int, int, bool) {
+	m := zhClockRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, 0, false
+	}
+	h, ok := parseZhNumber(m[2])
+	if !ok {
+		return 0, 0, 0, false
+	}
+	minute, sec := 0, 0
+	switch {
+	case m[3] != "":
+		minute, _ = strconv.Atoi(m[3])
+		sec, _ = strconv.Atoi(m[4])
+	case m[5] != "":
+		if minute, ok = parseZhNumber(m[5]); !ok {
+			return 0, 0, 0, false
+		}
+	case m[6] != "":
+		minute = 30
+	}
+	switch m[1] {
+	case "下午", "晚上":
+		if h < 12 {
+			h += 12
+		}
+	case "中午":
+		if h < 11 {
+			h += 12
+		}
+	case "凌晨", "早上", "上午":
+		if h == 12 {
+			h = 0
+		}
+	}
+	if h > 23 || minute > 59 || sec > 59 {
+		return 0, 0, 0, false
+	}
+	return h, minute, sec, true
+}
+
+func parseEnClock(s string) (int, int, int, bool) {
+	switch s {
+	case "noon":
+		return 12, 0, 0, true
+	case "midnight":
+		return 0, 0, 0, true
+	}
+	m := enClockRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, 0, false
+	}
+	h, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	sec, _ := strconv.Atoi(m[3])
+	if m[4] != "" && (h < 1 || h > 12) {
+		return 0, 0, 0, false
+	}
+	switch m[4] {
+	case "am":
+		if h == 12 {
+			h = 0
+		}
+	case "pm":
+		if h < 12 {
+			h += 12
+		}
+	}
+	if h > 23 || minute > 59 || sec > 59 {
+		return 0, 0, 0, false
+	}
+	return h, minute, sec, true
+}
+
+// parseZhNumber 解析阿拉伯数字或 999 以内的中文数字，如「十五」「两」「一百零三」。
+func parseZhNumber(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	digits := map[rune]int{'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	total, cur := 0, -1
+	for _, r := range s {
+		if d, ok := digits[r]; ok {
+			cur = d
+			continue
+		}
+		unit := map[rune]int{'十': 10, '百': 100}[r]
+		if unit == 0 {
+			return 0, false
+		}
+		if cur < 0 {
+			cur = 1 // 「十五」省略了「一」
+		}
+		total += cur * unit
+		cur = -1
+	}
+	if cur > 0 {
+		total += cur
+	}
+	return total, s != ""
+}
@@ -0,0 +1,83 @@
+package datetimeutil
+
+import (
+	"testing"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRelative(t *testing.T) {
+	as := assert.New(t)
+	// 2025-03-12 为星期三
+	now := mustDT(2025, 3, 12, 16, 20, 0)
+	cases := map[string][6]int{
+		"刚刚":                   {2025, 3, 12, 16, 20, 0},
+		"3天后":                  {2025, 3, 15, 16, 20, 0},
+		"两小时前":                 {2025, 3, 12, 14, 20, 0},
+		"半小时后":                 {2025, 3, 12, 16, 50, 0},
+		"一个半小时前":               {2025, 3, 12, 14, 50, 0},
+		"十五分钟前":                {2025, 3, 12, 16, 5, 0},
+		"3个月后":                 {2025, 6, 12, 16, 20, 0},
+		"1年以前":                 {2024, 3, 12, 16, 20, 0},
+		"明天":                   {2025, 3, 13, 0, 0, 0},
+		"昨天 14:20":             {2025, 3, 11, 14, 20, 0},
+		"后天下午3点":               {2025, 3, 14, 15, 0, 0},
+		"明天早上八点半":              {2025, 3, 13, 8, 30, 0},
+		"下周一":                  {2025, 3, 17, 0, 0, 0},
+		"上周五 10:00":            {2025, 3, 7, 10, 0, 0},
+		"周日":                   {2025, 3, 16, 0, 0, 0},
+		"这周一":                  {2025, 3, 10, 0, 0, 0},
+		"下个月":                  {2025, 4, 12, 16, 20, 0},
+		"去年":                   {2024, 3, 12, 16, 20, 0},
+		"now":                  {2025, 3, 12, 16, 20, 0},
+		"in 2 hours":           {2025, 3, 12, 18, 20, 0},
+		"3 days ago":           {2025, 3, 9, 16, 20, 0},
+		"a week from now":      {2025, 3, 19, 16, 20, 0},
+		"Next Monday":          {2025, 3, 17, 0, 0, 0},
+		"next wednesday":       {2025, 3, 19, 0, 0, 0},
+		"tues at noon":         {2025, 3, 18, 12, 0, 0},
+		"last wednesday":       {2025, 3, 5, 0, 0, 0},
+		"this friday":          {2025, 3, 14, 0, 0, 0},
+		"wednesday":            {2025, 3, 12, 0, 0, 0},
+		"tomorrow at 9am":      {2025, 3, 13, 9, 0, 0},
+		"yesterday at 12:30pm": {2025, 3, 11, 12, 30, 0},
+		"last month":           {2025, 2, 12, 16, 20, 0},
+	}
+	for s, want := range cases {
+		got, err := ParseRelative(s, now)
+		if as.NoError(err, s) {
+			as.Equal(mustDT(want[0], want[1], want[2], want[3], want[4], want[5]), got, s)
+		}
+	}
+
+	// 月末取当月最后一天
+	got, err := ParseRelative("1个月后", mustDT(2025, 1, 31, 8, 0, 0))
+	as.NoError(err)
+	as.Equal(mustDT(2025, 2, 28, 8, 0, 0), got)
+
+	for _, s := range []string{"", "后", "3天", "in 3 days ago", "明天 25点", "tomorrow at 13pm", "下周八"} {
+		_, err := ParseRelative(s, now)
+		as.ErrorIs(err, ErrInvalidRelative, s)
+	}
+}
+
+func TestParseRelative_localGap(t *testing.T) {
+	as := assert.New(t)
+	// 纽约 2025-03-09 02:30 不存在，无法用 types.LocalDateTime 表示
+	setLocal(t, "America/New_York")
+	for _, c := range []struct {
+		s   string
+		now types.LocalDateTime
+	}{
+		{"明天2点30", mustDT(2025, 3, 8, 10, 0, 0)},
+		{"1天后", mustDT(2025, 3, 8, 2, 30, 0)},
+		{"1个月后", mustDT(2025, 2, 9, 2, 30, 0)},
+	} {
+		_, err := ParseRelative(c.s, c.now)
+		as.ErrorIs(err, ErrNonexistentTime, c.s)
+	}
+	got, err := ParseRelative("明天3点30", mustDT(2025, 3, 8, 10, 0, 0))
+	as.NoError(err)
+	as.Equal(mustDT(2025, 3, 9, 3, 30, 0), got)
+}
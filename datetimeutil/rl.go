@@ -1,23 +1,36 @@
 package datetimeutil
 
-import "github.com/lontten/lcore/v2/types"
+import (
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
+)
 
 // IsNowR 判断时间是否在 当前时间 右侧，之后
 func IsNowR(v *types.LocalDateTime) bool {
+	return IsNowRWithClock(clockutil.Real, v)
+}
+
+// IsNowRWithClock 同 IsNowR，以时钟 c 的当前时间为基准
+func IsNowRWithClock(c clockutil.Clock, v *types.LocalDateTime) bool {
 	if v == nil {
 		return false
 	}
-	now := types.NowDateTime()
+	now := clockutil.NowDateTime(c)
 
 	return v.After(now)
 }
 
 // IsNowL 判断时间是否在 当前时间 左侧，之前
 func IsNowL(v *types.LocalDateTime) bool {
+	return IsNowLWithClock(clockutil.Real, v)
+}
+
+// IsNowLWithClock 同 IsNowL，以时钟 c 的当前时间为基准
+func IsNowLWithClock(c clockutil.Clock, v *types.LocalDateTime) bool {
 	if v == nil {
 		return false
 	}
-	now := types.NowDateTime()
+	now := clockutil.NowDateTime(c)
 
 	return v.Before(now)
 }
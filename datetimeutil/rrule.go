@@ -0,0 +1,795 @@
+package datetimeutil
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// ErrInvalidRRule 非法或不支持的重复规则。
+var ErrInvalidRRule = errors.New("非法的重复规则")
+
+// Frequency 重复频率（RFC 5545 FREQ）。
+type Frequency int
+
+const (
+	Secondly Frequency = iota + 1
+	Minutely
+	Hourly
+	Daily
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Secondly: "SECONDLY", Minutely: "MINUTELY", Hourly: "HOURLY",
+	Daily: "DAILY", Weekly: "WEEKLY", Monthly: "MONTHLY", Yearly: "YEARLY",
+}
+
+// String 返回 RFC 5545 中的频率名，如 WEEKLY。
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayCodes = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum BYDAY 中的一项，如 MO、2TU（第二个周二）、-1FR（最后一个周五）。
+type WeekdayNum struct {
+	N   int // 0 表示周期内的每一个该星期
+	Day time.Weekday
+}
+
+// String 返回 RFC 5545 形式，如 -1FR。
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayCodes[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayCodes[w.Day]
+}
+
+// RRule 重复规则（RFC 5545 RRULE），支持 FREQ、INTERVAL、COUNT、UNTIL、BYDAY、
+// BYMONTHDAY、BYMONTH、BYSETPOS、WKST。
+type RRule struct {
+	Freq       Frequency
+	Interval   int                 // 0 视为 1
+	Count      int                 // 0 表示不限
+	Until      types.LocalDateTime // 零值表示不限；含当天时刻
+	ByDay      []WeekdayNum
+	ByMonthDay []int // 1~31 或 -31~-1（-1 为月末）
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday // WKST，默认周一
+}
+
+// ParseRRule 解析 RRULE 值，如 "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6"，可带 "RRULE:" 前缀。
+// UNTIL 以 Z 结尾时按 UTC 解释并转换到 loc（nil 为 time.Local）。
+func ParseRRule(s string, loc ...*time.Location) (RRule, error) {
+	l := time.Local
+	if len(loc) > 0 && loc[0] != nil {
+		l = loc[0]
+	}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	r := RRule{WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for part := range strings.SplitSeq(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(key)
+		if !ok || val == "" {
+			return RRule{}, fmt.Errorf("%w: %q", ErrInvalidRRule, part)
+		}
+		if seen[key] {
+			return RRule{}, fmt.Errorf("%w: %s 重复", ErrInvalidRRule, key)
+		}
+		seen[key] = true
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq = 0
+			for f, name := range frequencyNames {
+				if strings.EqualFold(name, val) {
+					r.Freq = f
+				}
+			}
+			if r.Freq == 0 {
+				err = fmt.Errorf("未知频率 %q", val)
+			}
+		case "INTERVAL":
+			r.Interval, err = parsePositive(val)
+		case "COUNT":
+			r.Count, err = parsePositive(val)
+		case "UNTIL":
+			r.Until, err = parseICalTime(val, l)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(val, -31, 31)
+		case "BYMONTH":
+			r.ByMonth, err = parseIntList(val, 1, 12)
+		case "BYSETPOS":
+			r.BySetPos, err = parseIntList(val, -366, 366)
+		case "WKST":
+			var wd WeekdayNum
+			if wd, err = parseWeekdayNum(val); err == nil && wd.N != 0 {
+				err = fmt.Errorf("WKST 不能带序号")
+			}
+			r.WeekStart = wd.Day
+		default:
+			err = fmt.Errorf("不支持 %s", key)
+		}
+		if err != nil {
+			return RRule{}, fmt.Errorf("%w: %v", ErrInvalidRRule, err)
+		}
+	}
+	if err := r.Validate(); err != nil {
+		return RRule{}, err
+	}
+	return r, nil
+}
+
+// Validate 校验规则组合是否合法。
+func (r RRule) Validate() error {
+	switch {
+	case r.Freq < Secondly || r.Freq > Yearly:
+		return fmt.Errorf("%w: 缺少 FREQ", ErrInvalidRRule)
+	case r.Interval < 0 || r.Count < 0:
+		return fmt.Errorf("%w: INTERVAL、COUNT 不能为负", ErrInvalidRRule)
+	case r.Count > 0 && !r.Until.IsZero():
+		return fmt.Errorf("%w: COUNT 与 UNTIL 不能同时出现", ErrInvalidRRule)
+	case len(r.BySetPos) > 0 && len(r.ByDay)+len(r.ByMonthDay)+len(r.ByMonth) == 0:
+		return fmt.Errorf("%w: BYSETPOS 需与其他 BYxxx 一起使用", ErrInvalidRRule)
+	case r.Freq == Weekly && len(r.ByMonthDay) > 0:
+		return fmt.Errorf("%w: WEEKLY 不能使用 BYMONTHDAY", ErrInvalidRRule)
+	case r.Freq <= Daily && slices.ContainsFunc(r.BySetPos, func(p int) bool { return p != 1 && p != -1 }):
+		return fmt.Errorf("%w: %s 每个周期只有一个候选，BYSETPOS 只能为 1 或 -1", ErrInvalidRRule, r.Freq)
+	case !r.monthDayPossible():
+		return fmt.Errorf("%w: BYMONTH 中的月份没有 BYMONTHDAY 指定的日", ErrInvalidRRule)
+	}
+	if r.Freq != Monthly && r.Freq != Yearly {
+		for _, d := range r.ByDay {
+			if d.N != 0 {
+				return fmt.Errorf("%w: %s 的 BYDAY 不能带序号 %s", ErrInvalidRRule, r.Freq, d)
+			}
+		}
+	}
+	for _, v := range slices.Concat(r.ByMonthDay, r.BySetPos) {
+		if v == 0 {
+			return fmt.Errorf("%w: BYMONTHDAY、BYSETPOS 不能为 0", ErrInvalidRRule)
+		}
+	}
+	return nil
+}
+
+// monthDayPossible 判断 BYMONTHDAY 是否至少在 BYMONTH 的某个月（按闰年计）存在，如 2 月 30 日永远不会出现。
+func (r RRule) monthDayPossible() bool {
+	if len(r.ByMonthDay) == 0 || len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		last := time.Date(2024, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		for _, md := range r.ByMonthDay {
+			if md <= last && -md <= last {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String 返回 RRULE 值（不含 "RRULE:" 前缀）；UNTIL 以本地时刻输出。
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.ToGoTime().Format("20060102T150405"))
+	}
+	join := func(name string, vals []int) {
+		if len(vals) > 0 {
+			s := make([]string, len(vals))
+			for i, v := range vals {
+				s[i] = strconv.Itoa(v)
+			}
+			parts = append(parts, name+"="+strings.Join(s, ","))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		s := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			s[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(s, ","))
+	}
+	join("BYMONTHDAY", r.ByMonthDay)
+	join("BYMONTH", r.ByMonth)
+	join("BYSETPOS", r.BySetPos)
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCodes[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// WorkdayCalendar 判断工作日的日历，*dateutil.WorkCalendar 即满足此接口。
+type WorkdayCalendar interface {
+	IsWorkday(d types.LocalDate) bool
+}
+
+// HolidayAdjust 重复事件落在非工作日时的处理方式。
+type HolidayAdjust int
+
+const (
+	HolidaySkip HolidayAdjust = iota // 跳过该次
+	HolidayNext                      // 顺延到下一个工作日
+	HolidayPrev                      // 提前到上一个工作日
+)
+
+// Recurrence 从 Start 开始按 Rule 重复的事件，结果为 Location 中的本地日期时间。
+// Start 本身仅在符合规则时才作为一次发生（与 python-dateutil 一致）；COUNT 统计排除 ExDates 之前的次数。
+type Recurrence struct {
+	Rule     RRule
+	Start    types.LocalDateTime   // DTSTART，Location 中的墙上时钟
+	Location *time.Location        // nil 为 time.Local
+	ExDates  []types.LocalDateTime // 排除的发生时刻（EXDATE）
+	Calendar WorkdayCalendar       // 非 nil 时按 Adjust 处理非工作日
+	Adjust   HolidayAdjust
+}
+
+// NewRecurrence 创建从 start 开始按 rule 重复的事件。
+func NewRecurrence(start types.LocalDateTime, rule RRule) *Recurrence {
+	return &Recurrence{Rule: rule, Start: start}
+}
+
+// ParseRecurrence 解析 iCalendar 片段，包含 DTSTART、RRULE 与可选的 EXDATE 行，如：
+//
+//	DTSTART;TZID=Asia/Shanghai:20250106T090000
+//	RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10
+//	EXDATE;TZID=Asia/Shanghai:20250108T090000
+//
+// 时区取自 DTSTART 的 TZID，无 TZID 时为 time.Local；以 Z 结尾的时刻转换到该时区。
+func ParseRecurrence(text string) (*Recurrence, error) {
+	r := &Recurrence{}
+	var start, rule string
+	var exdates []string
+	for line := range strings.Lines(text) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		head, val, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRRule, line)
+		}
+		name, params, _ := strings.Cut(head, ";")
+		switch strings.ToUpper(name) {
+		case "DTSTART":
+			for p := range strings.SplitSeq(params, ";") {
+				if k, v, _ := strings.Cut(p, "="); strings.EqualFold(k, "TZID") {
+					loc, err := time.LoadLocation(v)
+					if err != nil {
+						return nil, fmt.Errorf("%w: %v", ErrInvalidRRule, err)
+					}
+					r.Location = loc
+				}
+			}
+			start = val
+		case "RRULE":
+			rule = val
+		case "EXDATE":
+			exdates = append(exdates, val)
+		default:
+			return nil, fmt.Errorf("%w: 不支持 %s", ErrInvalidRRule, name)
+		}
+	}
+	if start == "" || rule == "" {
+		return nil, fmt.Errorf("%w: 缺少 DTSTART 或 RRULE", ErrInvalidRRule)
+	}
+	loc := r.location()
+	var err error
+	if r.Start, err = parseICalTime(start, loc); err != nil {
+		return nil, fmt.Errorf("%w: DTSTART %v", ErrInvalidRRule, err)
+	}
+	for _, v := range exdates {
+		for item := range strings.SplitSeq(v, ",") {
+			t, err := parseICalTime(item, loc)
+			if err != nil {
+				return nil, fmt.Errorf("%w: EXDATE %v", ErrInvalidRRule, err)
+			}
+			r.ExDates = append(r.ExDates, t)
+		}
+	}
+	if r.Rule, err = ParseRRule(rule, loc); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// All 按时间顺序遍历全部发生时刻；无 COUNT/UNTIL 的规则需由调用方中断。
+// types.LocalDateTime 以 time.Local 校验，落在 time.Local 夏令时跳过时段内的发生时刻无法表示，会被跳过。
+func (r *Recurrence) All() iter.Seq[types.LocalDateTime] {
+	return r.occurrences(time.Time{})
+}
+
+// occurrences 同 All；无 COUNT 时直接从 from（UTC 墙上时钟）所在的周期开始展开，
+// 产出的时刻可能早于 from，由调用方过滤。
+func (r *Recurrence) occurrences(from time.Time) iter.Seq[types.LocalDateTime] {
+	return func(yield func(types.LocalDateTime) bool) {
+		var last time.Time
+		r.expand(from, func(t time.Time) bool {
+			if t.Equal(last) {
+				// 节假日顺延/提前后可能与相邻次重合
+				return true
+			}
+			last = t
+			v, err := localDateTimeOf(t)
+			return err != nil || yield(v)
+		})
+	}
+}
+
+// Between 返回 [from, to] 内的发生时刻。
+func (r *Recurrence) Between(from, to types.LocalDateTime) []types.LocalDateTime {
+	var out []types.LocalDateTime
+	for t := range r.occurrences(wallUTC(from)) {
+		if t.After(to) {
+			break
+		}
+		if !t.Before(from) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Next 返回 after 之后（不含）的第一次发生时刻，规则已结束时返回 false；
+// 连续大量周期都没有发生时刻（如工作日历排除了全部候选）时也视为已结束。
+func (r *Recurrence) Next(after types.LocalDateTime) (types.LocalDateTime, bool) {
+	for t := range r.occurrences(wallUTC(after)) {
+		if t.After(after) {
+			return t, true
+		}
+	}
+	return types.LocalDateTime{}, false
+}
+
+// Instant 返回本地日期时间 t 在 Location 中对应的时刻，夏令时跳过、重复时段按 DSTCompatible 处理。
+func (r *Recurrence) Instant(t types.LocalDateTime) time.Time {
+	u, _ := ToInstant(t, r.location())
+	return u
+}
+
+func (r *Recurrence) location() *time.Location {
+	if r.Location != nil {
+		return r.Location
+	}
+	return time.Local
+}
+
+// maxRecurYear 展开的年份上限，无 COUNT/UNTIL 且不再命中的规则（如节假日全部排除）展开到此为止。
+const maxRecurYear = 9999
+
+// periodSeconds DAILY 及更细频率每个周期的秒数。
+var periodSeconds = map[Frequency]int64{Daily: 86400, Hourly: 3600, Minutely: 60, Secondly: 1}
+
+// maxEmptyPeriods 连续没有产出的周期数上限，超过时视为规则已不再命中（如工作日历排除了全部候选）。
+const maxEmptyPeriods = 10000
+
+// expand 按顺序产出发生时刻（UTC 墙上时钟表示）；无 COUNT 时从 from 所在的周期开始。
+func (r *Recurrence) expand(from time.Time, yield func(time.Time) bool) {
+	rule := r.Rule
+	if rule.Validate() != nil {
+		return
+	}
+	interval := max(rule.Interval, 1)
+	loc := r.location()
+	start := wallUTC(r.Start)
+	until := time.Time{}
+	if !rule.Until.IsZero() {
+		until = wallUTC(rule.Until)
+	}
+	ex := make(map[time.Time]bool, len(r.ExDates))
+	for _, e := range r.ExDates {
+		ex[wallUTC(e)] = true
+	}
+
+	count, empty := 0, 0
+	i := 0
+	if rule.Count == 0 && from.After(start) {
+		i = r.seekPeriod(start, r.seekFrom(from), interval)
+	}
+	for {
+		periodStart, candidates := r.period(start, i*interval)
+		if periodStart.Year() > maxRecurYear || !until.IsZero() && periodStart.After(until) {
+			return
+		}
+		if empty++; empty > maxEmptyPeriods {
+			return
+		}
+		for _, c := range candidates {
+			if c.Before(start) {
+				continue
+			}
+			if !until.IsZero() && c.After(until) {
+				return
+			}
+			count++
+			// DST 跳过的时刻按跳变前的偏移解释，即向后顺延（RFC 5545 3.3.5）
+			u, _ := resolveWallIn(c, loc, DSTCompatible)
+			w := wallClock(u)
+			if !ex[c] && !ex[w] {
+				if w, ok := r.adjust(w); ok {
+					if !yield(w) {
+						return
+					}
+					empty = 0
+				}
+			}
+			if rule.Count > 0 && count >= rule.Count {
+				return
+			}
+		}
+		i = r.nextPeriod(start, periodStart, i, interval)
+	}
+}
+
+// seekFrom 返回展开的起点：顺延到工作日时，from 之前紧邻的连续非工作日上的发生时刻可能顺延到 from 之后，需从这些日子开始。
+func (r *Recurrence) seekFrom(from time.Time) time.Time {
+	if r.Calendar == nil || r.Adjust != HolidayNext {
+		return from
+	}
+	for range 366 {
+		d := dayStart(from).AddDate(0, 0, -1)
+		if r.Calendar.IsWorkday(types.LocalDateOfYmd(d.Year(), int(d.Month()), d.Day())) {
+			break
+		}
+		from = d
+	}
+	return from
+}
+
+// seekPeriod 返回起点不晚于 from 的最后一个周期的序号（未乘 INTERVAL）。
+func (r *Recurrence) seekPeriod(start, from time.Time, interval int) int {
+	var n int64
+	switch r.Rule.Freq {
+	case Yearly:
+		n = int64(from.Year() - start.Year())
+	case Monthly:
+		n = int64((from.Year()-start.Year())*12 + int(from.Month()) - int(start.Month()))
+	case Weekly:
+		first, _ := r.period(start, 0)
+		n = (from.Unix() - first.Unix()) / (7 * 86400)
+	case Daily:
+		n = (from.Unix() - dayStart(start).Unix()) / 86400
+	default:
+		n = (from.Unix() - start.Unix()) / periodSeconds[r.Rule.Freq]
+	}
+	return max(int(n)/interval, 0)
+}
+
+// nextPeriod 返回第 i 个周期之后要展开的周期序号（未乘 INTERVAL）。DAILY 及更细频率下，
+// 若 periodStart 所在的月或日不满足 BYxxx 或被工作日历跳过，直接跳到下个月或下一天，避免逐日、逐秒空转。
+func (r *Recurrence) nextPeriod(start, periodStart time.Time, i, interval int) int {
+	step, ok := periodSeconds[r.Rule.Freq]
+	if !ok {
+		return i + 1
+	}
+	day := dayStart(periodStart)
+	var target time.Time
+	switch {
+	case !r.monthOK(day.Month()):
+		target = time.Date(day.Year(), day.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	case !r.dayOK(day) || r.Calendar != nil && r.Adjust == HolidaySkip &&
+		!r.Calendar.IsWorkday(types.LocalDateOfYmd(day.Year(), int(day.Month()), day.Day())):
+		target = day.AddDate(0, 0, 1)
+	default:
+		return i + 1
+	}
+	if r.Rule.Freq == Daily {
+		// DAILY 的周期从 DTSTART 当天零点起算
+		start = dayStart(start)
+	}
+	span := step * int64(interval)
+	n := (target.Unix() - start.Unix() + span - 1) / span
+	return max(int(n), i+1)
+}
+
+// adjust 按工作日历处理非工作日。
+func (r *Recurrence) adjust(w time.Time) (time.Time, bool) {
+	if r.Calendar == nil {
+		return w, true
+	}
+	step := 0
+	switch r.Adjust {
+	case HolidayNext:
+		step = 1
+	case HolidayPrev:
+		step = -1
+	}
+	for range 366 {
+		if r.Calendar.IsWorkday(types.LocalDateOfYmd(w.Year(), int(w.Month()), w.Day())) {
+			return w, true
+		}
+		if step == 0 {
+			return w, false
+		}
+		w = w.AddDate(0, 0, step)
+	}
+	return w, false
+}
+
+// period 返回第 n 个周期（n 已乘以 INTERVAL）的起点与周期内按顺序排列的候选时刻。
+func (r *Recurrence) period(start time.Time, n int) (time.Time, []time.Time) {
+	rule := r.Rule
+	h, mi, s := start.Clock()
+	at := func(days []time.Time) []time.Time {
+		days = applySetPos(days, rule.BySetPos)
+		for i, d := range days {
+			days[i] = d.Add(time.Duration(h)*time.Hour + time.Duration(mi)*time.Minute + time.Duration(s)*time.Second)
+		}
+		return days
+	}
+	day := dayStart(start)
+
+	switch rule.Freq {
+	case Yearly:
+		y := start.Year() + n
+		first := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC)
+		return first, at(r.yearDays(y, start))
+	case Monthly:
+		first := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+		if !r.monthOK(first.Month()) {
+			return first, nil
+		}
+		return first, at(r.monthDays(first.Year(), first.Month(), start))
+	case Weekly:
+		back := (int(day.Weekday()) - int(rule.WeekStart) + 7) % 7
+		first := day.AddDate(0, 0, -back+7*n)
+		var days []time.Time
+		for d := range 7 {
+			t := first.AddDate(0, 0, d)
+			if r.monthOK(t.Month()) && (len(rule.ByDay) == 0 && t.Weekday() == start.Weekday() || r.weekdayOK(t)) {
+				days = append(days, t)
+			}
+		}
+		return first, at(days)
+	case Daily:
+		t := day.AddDate(0, 0, n)
+		if r.dayOK(t) {
+			return t, at([]time.Time{t})
+		}
+		return t, nil
+	}
+
+	t := start.Add(time.Duration(n) * time.Duration(periodSeconds[rule.Freq]) * time.Second)
+	if r.dayOK(dayStart(t)) {
+		return t, applySetPos([]time.Time{t}, rule.BySetPos)
+	}
+	return t, nil
+}
+
+// yearDays 返回 YEARLY 规则在 y 年的候选日期。
+func (r *Recurrence) yearDays(y int, start time.Time) []time.Time {
+	rule := r.Rule
+	if len(rule.ByMonth) == 0 && len(rule.ByMonthDay) == 0 && len(rule.ByDay) > 0 {
+		// BYDAY 序号相对全年
+		var days []time.Time
+		for _, wd := range rule.ByDay {
+			days = append(days, nthWeekdays(time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC), wd)...)
+		}
+		return sortUnique(days)
+	}
+	var days []time.Time
+	switch {
+	case len(rule.ByMonth) > 0:
+		for m := 1; m <= 12; m++ {
+			if r.monthOK(time.Month(m)) {
+				days = append(days, r.monthDays(y, time.Month(m), start)...)
+			}
+		}
+	case len(rule.ByMonthDay) > 0:
+		for m := 1; m <= 12; m++ {
+			days = append(days, r.monthDays(y, time.Month(m), start)...)
+		}
+	default:
+		if t := time.Date(y, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC); t.Day() == start.Day() {
+			days = append(days, t)
+		}
+	}
+	return days
+}
+
+// monthDays 返回某月的候选日期；无 BYMONTHDAY、BYDAY 时取 DTSTART 的日，该月没有这一天则跳过。
+func (r *Recurrence) monthDays(y int, m time.Month, start time.Time) []time.Time {
+	rule := r.Rule
+	first := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	next := first.AddDate(0, 1, 0)
+	last := next.AddDate(0, 0, -1).Day()
+
+	var days []time.Time
+	switch {
+	case len(rule.ByMonthDay) > 0:
+		for _, md := range rule.ByMonthDay {
+			d := md
+			if md < 0 {
+				d = last + md + 1
+			}
+			if d >= 1 && d <= last {
+				t := first.AddDate(0, 0, d-1)
+				if len(rule.ByDay) == 0 || r.weekdayOK(t) {
+					days = append(days, t)
+				}
+			}
+		}
+	case len(rule.ByDay) > 0:
+		for _, wd := range rule.ByDay {
+			days = append(days, nthWeekdays(first, next, wd)...)
+		}
+	default:
+		if start.Day() <= last {
+			days = append(days, first.AddDate(0, 0, start.Day()-1))
+		}
+	}
+	return sortUnique(days)
+}
+
+func (r *Recurrence) monthOK(m time.Month) bool {
+	return len(r.Rule.ByMonth) == 0 || slices.Contains(r.Rule.ByMonth, int(m))
+}
+
+func (r *Recurrence) weekdayOK(t time.Time) bool {
+	for _, wd := range r.Rule.ByDay {
+		if wd.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// dayOK 判断日期是否满足 DAILY 及更细频率下作为过滤条件的 BYMONTH、BYMONTHDAY、BYDAY。
+func (r *Recurrence) dayOK(t time.Time) bool {
+	rule := r.Rule
+	if !r.monthOK(t.Month()) || len(rule.ByDay) > 0 && !r.weekdayOK(t) {
+		return false
+	}
+	if len(rule.ByMonthDay) == 0 {
+		return true
+	}
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range rule.ByMonthDay {
+		if md == t.Day() || md < 0 && last+md+1 == t.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// nthWeekdays 返回 [from, to) 内符合 wd 的日期：N 为 0 时全部，正数取第 N 个，负数取倒数第 N 个。
+func nthWeekdays(from, to time.Time, wd WeekdayNum) []time.Time {
+	var all []time.Time
+	t := from.AddDate(0, 0, (int(wd.Day)-int(from.Weekday())+7)%7)
+	for ; t.Before(to); t = t.AddDate(0, 0, 7) {
+		all = append(all, t)
+	}
+	switch {
+	case wd.N == 0:
+		return all
+	case wd.N > 0 && wd.N <= len(all):
+		return all[wd.N-1 : wd.N]
+	case wd.N < 0 && -wd.N <= len(all):
+		return all[len(all)+wd.N : len(all)+wd.N+1]
+	}
+	return nil
+}
+
+// applySetPos 按 BYSETPOS 从周期内的有序候选中取值。
+func applySetPos(days []time.Time, pos []int) []time.Time {
+	if len(pos) == 0 {
+		return days
+	}
+	var out []time.Time
+	for _, p := range pos {
+		i := p - 1
+		if p < 0 {
+			i = len(days) + p
+		}
+		if i >= 0 && i < len(days) {
+			out = append(out, days[i])
+		}
+	}
+	return sortUnique(out)
+}
+
+func sortUnique(days []time.Time) []time.Time {
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(days, func(a, b time.Time) bool { return a.Equal(b) })
+}
+
+func parsePositive(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("应为正整数: %q", s)
+	}
+	return n, nil
+}
+
+func parseIntList(s string, lo, hi int) ([]int, error) {
+	var out []int
+	for item := range strings.SplitSeq(s, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n < lo || n > hi {
+			return nil, fmt.Errorf("取值应在 %d~%d: %q", lo, hi, item)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func parseByDay(s string) ([]WeekdayNum, error) {
+	var out []WeekdayNum
+	for item := range strings.SplitSeq(s, ",") {
+		wd, err := parseWeekdayNum(item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, wd)
+	}
+	return out, nil
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("非法星期 %q", s)
+	}
+	day := slices.Index(weekdayCodes[:], s[len(s)-2:])
+	if day < 0 {
+		return WeekdayNum{}, fmt.Errorf("非法星期 %q", s)
+	}
+	n := 0
+	if num := s[:len(s)-2]; num != "" {
+		var err error
+		if n, err = strconv.Atoi(num); err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("非法星期序号 %q", s)
+		}
+	}
+	return WeekdayNum{N: n, Day: time.Weekday(day)}, nil
+}
+
+// parseICalTime 解析 20250106、20250106T090000 或 20250106T010000Z，Z 结尾的时刻转换到 loc。
+func parseICalTime(s string, loc *time.Location) (types.LocalDateTime, error) {
+	s = strings.TrimSpace(s)
+	layouts := []string{"20060102T150405Z", "20060102T150405", "20060102"}
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		if strings.HasSuffix(layout, "Z") {
+			t = t.In(loc)
+		}
+		return localDateTimeOf(t)
+	}
+	return types.LocalDateTime{}, fmt.Errorf("非法时刻 %q", s)
+}
+
+// localDateTimeOf 取 t 的墙上时钟构造 types.LocalDateTime。types.LocalDateTime 以 time.Local 校验，
+// 墙上时钟落在 time.Local 夏令时跳过的时段内时无法表示，返回 ErrNonexistentTime 而不是 panic。
+func localDateTimeOf(t time.Time) (types.LocalDateTime, error) {
+	w := wallClock(t)
+	if !wallClock(time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), 0, time.Local)).Equal(w) {
+		return types.LocalDateTime{}, fmt.Errorf("%w: %s 在 %s", ErrNonexistentTime, w.Format(time.DateTime), time.Local)
+	}
+	return types.LocalDateTimeOf(w), nil
+}
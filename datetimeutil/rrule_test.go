@@ -0,0 +1,250 @@
+package datetimeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/dateutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustRecur(t *testing.T, start types.LocalDateTime, rule string) *Recurrence {
+	t.Helper()
+	r, err := ParseRRule(rule)
+	require.NoError(t, err)
+	return NewRecurrence(start, r)
+}
+
+func collect(r *Recurrence, limit int) []types.LocalDateTime {
+	var out []types.LocalDateTime
+	for v := range r.All() {
+		out = append(out, v)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out
+}
+
+func TestRecurrenceExpand(t *testing.T) {
+	as := assert.New(t)
+
+	r := mustRecur(t, mustDT(2025, 1, 6, 9, 0, 0), "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=5")
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 1, 6, 9, 0, 0), mustDT(2025, 1, 8, 9, 0, 0), mustDT(2025, 1, 13, 9, 0, 0),
+		mustDT(2025, 1, 15, 9, 0, 0), mustDT(2025, 1, 20, 9, 0, 0),
+	}, collect(r, 0))
+
+	// 每月最后一个周五
+	r = mustRecur(t, mustDT(2025, 1, 1, 10, 0, 0), "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3")
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 1, 31, 10, 0, 0), mustDT(2025, 2, 28, 10, 0, 0), mustDT(2025, 3, 28, 10, 0, 0),
+	}, collect(r, 0))
+
+	// 每月最后一个工作日
+	r = mustRecur(t, mustDT(2025, 1, 1, 0, 0, 0), "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1")
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 1, 31, 0, 0, 0), mustDT(2025, 2, 28, 0, 0, 0), mustDT(2025, 3, 31, 0, 0, 0),
+		mustDT(2025, 4, 30, 0, 0, 0), mustDT(2025, 5, 30, 0, 0, 0),
+	}, collect(r, 5))
+
+	// 没有 31 日的月份跳过；BYMONTHDAY=-1 取月末
+	r = mustRecur(t, mustDT(2025, 1, 31, 8, 0, 0), "FREQ=MONTHLY;COUNT=3")
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 1, 31, 8, 0, 0), mustDT(2025, 3, 31, 8, 0, 0), mustDT(2025, 5, 31, 8, 0, 0),
+	}, collect(r, 0))
+	r = mustRecur(t, mustDT(2025, 1, 31, 8, 0, 0), "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2")
+	as.Equal([]types.LocalDateTime{mustDT(2025, 1, 31, 8, 0, 0), mustDT(2025, 2, 28, 8, 0, 0)}, collect(r, 0))
+
+	// 2 月 29 日只在闰年出现；11 月第四个周四
+	r = mustRecur(t, mustDT(2024, 2, 29, 0, 0, 0), "FREQ=YEARLY;COUNT=2")
+	as.Equal([]types.LocalDateTime{mustDT(2024, 2, 29, 0, 0, 0), mustDT(2028, 2, 29, 0, 0, 0)}, collect(r, 0))
+	r = mustRecur(t, mustDT(2025, 1, 1, 0, 0, 0), "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH")
+	as.Equal([]types.LocalDateTime{mustDT(2025, 11, 27, 0, 0, 0), mustDT(2026, 11, 26, 0, 0, 0)}, collect(r, 2))
+
+	r = mustRecur(t, mustDT(2025, 1, 1, 20, 0, 0), "FREQ=HOURLY;INTERVAL=8;COUNT=3")
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 1, 1, 20, 0, 0), mustDT(2025, 1, 2, 4, 0, 0), mustDT(2025, 1, 2, 12, 0, 0),
+	}, collect(r, 0))
+
+	// 2 月 29 日只在闰年出现，按分钟展开时整月、整天跳过
+	r = mustRecur(t, mustDT(2025, 3, 1, 0, 0, 0), "FREQ=MINUTELY;INTERVAL=30;BYMONTH=2;BYMONTHDAY=29")
+	as.Equal([]types.LocalDateTime{mustDT(2028, 2, 29, 0, 0, 0), mustDT(2028, 2, 29, 0, 30, 0)}, collect(r, 2))
+}
+
+func TestRecurrenceBetweenNext(t *testing.T) {
+	as := assert.New(t)
+	r := mustRecur(t, mustDT(2025, 1, 1, 9, 0, 0), "FREQ=DAILY;INTERVAL=2;UNTIL=20250109T090000")
+	r.ExDates = []types.LocalDateTime{mustDT(2025, 1, 5, 9, 0, 0)}
+
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 1, 1, 9, 0, 0), mustDT(2025, 1, 3, 9, 0, 0), mustDT(2025, 1, 7, 9, 0, 0), mustDT(2025, 1, 9, 9, 0, 0),
+	}, collect(r, 0))
+	as.Equal([]types.LocalDateTime{mustDT(2025, 1, 3, 9, 0, 0), mustDT(2025, 1, 7, 9, 0, 0)},
+		r.Between(mustDT(2025, 1, 2, 0, 0, 0), mustDT(2025, 1, 7, 9, 0, 0)))
+
+	next, ok := r.Next(mustDT(2025, 1, 3, 9, 0, 0))
+	as.True(ok)
+	as.Equal(mustDT(2025, 1, 7, 9, 0, 0), next)
+	_, ok = r.Next(mustDT(2025, 1, 9, 9, 0, 0))
+	as.False(ok)
+
+	// 每个工作日 9:00 起每小时一次
+	r = mustRecur(t, mustDT(2025, 1, 3, 9, 0, 0), "FREQ=HOURLY;BYDAY=MO,TU,WE,TH,FR")
+	next, ok = r.Next(mustDT(2025, 1, 3, 23, 0, 0))
+	as.True(ok)
+	as.Equal(mustDT(2025, 1, 6, 0, 0, 0), next)
+
+	// 每月 13 日每 15 分钟一次
+	r = mustRecur(t, mustDT(2025, 1, 13, 8, 5, 0), "FREQ=MINUTELY;INTERVAL=15;BYMONTHDAY=13")
+	next, ok = r.Next(mustDT(2025, 1, 13, 8, 30, 0))
+	as.True(ok)
+	as.Equal(mustDT(2025, 1, 13, 8, 35, 0), next)
+	next, ok = r.Next(mustDT(2025, 1, 13, 23, 50, 0))
+	as.True(ok)
+	as.Equal(mustDT(2025, 2, 13, 0, 5, 0), next)
+}
+
+// noWorkdays 没有任何工作日的日历。
+type noWorkdays struct{}
+
+func (noWorkdays) IsWorkday(types.LocalDate) bool { return false }
+
+func TestRecurrenceSeek(t *testing.T) {
+	as := assert.New(t)
+
+	// 无 COUNT 时直接定位到 after 所在周期，不从 DTSTART 逐个展开
+	r := mustRecur(t, mustDT(2025, 1, 1, 0, 0, 0), "FREQ=SECONDLY;INTERVAL=7")
+	next, ok := r.Next(mustDT(2025, 2, 1, 0, 0, 0))
+	as.True(ok)
+	as.Equal(mustDT(2025, 2, 1, 0, 0, 3), next)
+	r = mustRecur(t, mustDT(2025, 1, 1, 0, 0, 30), "FREQ=MINUTELY;BYDAY=MO")
+	next, ok = r.Next(mustDT(2026, 1, 1, 0, 0, 0))
+	as.True(ok)
+	as.Equal(mustDT(2026, 1, 5, 0, 0, 30), next)
+	as.Equal([]types.LocalDateTime{mustDT(2026, 1, 5, 0, 0, 30), mustDT(2026, 1, 5, 0, 1, 30)},
+		r.Between(mustDT(2026, 1, 1, 0, 0, 0), mustDT(2026, 1, 5, 0, 1, 30)))
+
+	// 与从 DTSTART 逐个展开的结果一致
+	cal := dateutil.DefaultWorkCalendar()
+	for _, c := range []struct {
+		rule   string
+		adjust HolidayAdjust
+	}{
+		{"FREQ=YEARLY;INTERVAL=2;BYMONTH=10;BYDAY=1MO", HolidaySkip},
+		{"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=-1", HolidayNext},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SA;WKST=SU", HolidayPrev},
+		{"FREQ=DAILY;INTERVAL=5", HolidayNext},
+		{"FREQ=HOURLY;INTERVAL=13;BYDAY=SA,SU", HolidaySkip},
+	} {
+		r := mustRecur(t, mustDT(2024, 9, 29, 10, 0, 0), c.rule)
+		r.Calendar, r.Adjust = cal, c.adjust
+		all := collect(r, 200)
+		for k := 1; k+3 < len(all); k += 7 {
+			got, ok := r.Next(all[k-1])
+			as.True(ok, c.rule)
+			as.Equal(all[k], got, "%s after %s", c.rule, all[k-1])
+			as.Equal(all[k:k+4], r.Between(all[k], all[k+3]), c.rule)
+		}
+	}
+
+	// 工作日历排除全部候选时不会一直展开到 9999 年
+	r = mustRecur(t, mustDT(2025, 1, 1, 9, 0, 0), "FREQ=WEEKLY")
+	r.Calendar = noWorkdays{}
+	_, ok = r.Next(mustDT(2025, 6, 1, 0, 0, 0))
+	as.False(ok)
+	r = mustRecur(t, mustDT(2025, 1, 1, 9, 0, 0), "FREQ=MINUTELY")
+	r.Calendar = noWorkdays{}
+	_, ok = r.Next(mustDT(2025, 6, 1, 0, 0, 0))
+	as.False(ok)
+}
+
+func TestRecurrenceLocalGap(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	// 修改全局 time.Local，不能 t.Parallel
+	setLocal(t, "America/New_York")
+
+	// 上海 03-09 02:30 在 time.Local（纽约）中不存在，无法用 types.LocalDateTime 表示，跳过而不是 panic
+	r, err := ParseRecurrence(`DTSTART;TZID=Asia/Shanghai:20250308T023000
+RRULE:FREQ=DAILY;COUNT=3`)
+	req.NoError(err)
+	as.Equal([]types.LocalDateTime{mustDT(2025, 3, 8, 2, 30, 0), mustDT(2025, 3, 10, 2, 30, 0)}, collect(r, 0))
+
+	_, err = ParseRecurrence(`DTSTART;TZID=Asia/Shanghai:20250301T023000
+RRULE:FREQ=DAILY;UNTIL=20250308T183000Z`)
+	as.ErrorIs(err, ErrInvalidRRule)
+}
+
+func TestRecurrenceTimeZone(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	r, err := ParseRecurrence(`DTSTART;TZID=Asia/Shanghai:20250101T090000
+RRULE:FREQ=DAILY;UNTIL=20250103T010000Z
+EXDATE:20250102T010000Z`)
+	req.NoError(err)
+	as.Equal("Asia/Shanghai", r.Location.String())
+	as.Equal([]types.LocalDateTime{mustDT(2025, 1, 1, 9, 0, 0), mustDT(2025, 1, 3, 9, 0, 0)}, collect(r, 0))
+	as.True(r.Instant(mustDT(2025, 1, 3, 9, 0, 0)).Equal(time.Date(2025, 1, 3, 1, 0, 0, 0, time.UTC)))
+
+	// 纽约 2025-03-09 02:30 因夏令时不存在，按跳变前偏移顺延到 03:30
+	ny, err := time.LoadLocation("America/New_York")
+	req.NoError(err)
+	r = mustRecur(t, mustDT(2025, 3, 8, 2, 30, 0), "FREQ=DAILY;COUNT=3")
+	r.Location = ny
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 3, 8, 2, 30, 0), mustDT(2025, 3, 9, 3, 30, 0), mustDT(2025, 3, 10, 2, 30, 0),
+	}, collect(r, 0))
+	// 11-02 01:30 出现两次，取较早的夏令时
+	as.True(r.Instant(mustDT(2025, 11, 2, 1, 30, 0)).Equal(time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC)))
+}
+
+func TestRecurrenceWorkCalendar(t *testing.T) {
+	as := assert.New(t)
+	r := mustRecur(t, mustDT(2025, 9, 24, 10, 0, 0), "FREQ=WEEKLY;COUNT=4")
+	r.Calendar = dateutil.DefaultWorkCalendar()
+
+	// 10-01、10-08 为国庆假期
+	as.Equal([]types.LocalDateTime{mustDT(2025, 9, 24, 10, 0, 0), mustDT(2025, 10, 15, 10, 0, 0)}, collect(r, 0))
+
+	r.Adjust = HolidayNext
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 9, 24, 10, 0, 0), mustDT(2025, 10, 9, 10, 0, 0), mustDT(2025, 10, 15, 10, 0, 0),
+	}, collect(r, 0))
+
+	r.Adjust = HolidayPrev
+	as.Equal([]types.LocalDateTime{
+		mustDT(2025, 9, 24, 10, 0, 0), mustDT(2025, 9, 30, 10, 0, 0), mustDT(2025, 10, 15, 10, 0, 0),
+	}, collect(r, 0))
+}
+
+func TestParseRRule(t *testing.T) {
+	as := assert.New(t)
+
+	r, err := ParseRRule("RRULE:FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR,2MO;BYSETPOS=1;WKST=SU")
+	as.NoError(err)
+	as.Equal(Monthly, r.Freq)
+	as.Equal([]WeekdayNum{{N: -1, Day: time.Friday}, {N: 2, Day: time.Monday}}, r.ByDay)
+	as.Equal("FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR,2MO;BYSETPOS=1;WKST=SU", r.String())
+
+	for _, s := range []string{
+		"INTERVAL=2",
+		"FREQ=DAILY;COUNT=3;UNTIL=20250101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=HOURLY;BYDAY=MO;BYSETPOS=2",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ=HOURLY;BYMONTH=4,6;BYMONTHDAY=31,-31",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=DAILY;FREQ=WEEKLY",
+	} {
+		_, err := ParseRRule(s)
+		as.ErrorIs(err, ErrInvalidRRule, s)
+	}
+	_, err = ParseRecurrence("RRULE:FREQ=DAILY")
+	as.ErrorIs(err, ErrInvalidRRule)
+}
@@ -0,0 +1,153 @@
+package datetimeutil
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+var (
+	// ErrNonexistentTime 墙上时钟落在夏令时跳过的时段内，该时区不存在此时刻。
+	ErrNonexistentTime = errors.New("时区中不存在该时刻")
+	// ErrAmbiguousTime 墙上时钟落在夏令时回拨重复的时段内，对应两个时刻。
+	ErrAmbiguousTime = errors.New("时区中该时刻有歧义")
+)
+
+// DSTPolicy 墙上时钟落在夏令时跳过或重复时段时的处理方式，语义同 JavaScript Temporal 的 disambiguation。
+type DSTPolicy int
+
+const (
+	// DSTCompatible 重复时取较早者，跳过时按跳变前的偏移顺延（如 02:30 → 03:30），与 java.time、RFC 5545 一致。
+	DSTCompatible DSTPolicy = iota
+	// DSTEarlier 取较早的时刻：重复时取第一次，跳过时按跳变后的偏移提前（如 02:30 → 01:30）。
+	DSTEarlier
+	// DSTLater 取较晚的时刻：重复时取第二次，跳过时按跳变前的偏移顺延（如 02:30 → 03:30）。
+	DSTLater
+	// DSTReject 跳过或重复时返回 ErrNonexistentTime / ErrAmbiguousTime。
+	DSTReject
+)
+
+type zoneOpts struct {
+	policy DSTPolicy
+}
+
+// ZoneOpts 创建时区转换选项，默认 DSTCompatible。
+func ZoneOpts() *zoneOpts {
+	return &zoneOpts{policy: DSTCompatible}
+}
+
+// DST 设置夏令时跳过、重复时段的处理方式。
+func (o *zoneOpts) DST(p DSTPolicy) *zoneOpts {
+	o.policy = p
+	return o
+}
+
+func resolveZoneOpts(opts ...*zoneOpts) *zoneOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return ZoneOpts()
+	}
+	return opts[0]
+}
+
+var locationCache sync.Map
+
+// LoadLocation 按 IANA 名称加载时区，如 "Asia/Shanghai"，结果会被缓存。
+func LoadLocation(name string) (*time.Location, error) {
+	if v, ok := locationCache.Load(name); ok {
+		return v.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locationCache.Store(name, loc)
+	return loc, nil
+}
+
+// ToInstant 将 loc 中的墙上时钟 t 转换为时刻，夏令时跳过、重复时段按选项处理。
+func ToInstant(t types.LocalDateTime, loc *time.Location, opts ...*zoneOpts) (time.Time, error) {
+	return resolveWallIn(wallUTC(t), loc, resolveZoneOpts(opts...).policy)
+}
+
+// ToUTC 同 ToInstant，返回 UTC 时刻。
+func ToUTC(t types.LocalDateTime, loc *time.Location, opts ...*zoneOpts) (time.Time, error) {
+	u, err := ToInstant(t, loc, opts...)
+	return u.UTC(), err
+}
+
+// FromInstant 返回时刻 t 在 loc 中的墙上时钟。types.LocalDateTime 以 time.Local 校验，
+// 该墙上时钟落在 time.Local 夏令时跳过的时段内时无法表示，返回 ErrNonexistentTime。
+func FromInstant(t time.Time, loc *time.Location) (types.LocalDateTime, error) {
+	return localDateTimeOf(t.In(loc))
+}
+
+// ConvertZone 将 from 中的墙上时钟 t 转换为 to 中的墙上时钟，如北京时间换算为纽约时间。
+func ConvertZone(t types.LocalDateTime, from, to *time.Location, opts ...*zoneOpts) (types.LocalDateTime, error) {
+	u, err := ToInstant(t, from, opts...)
+	if err != nil {
+		return types.LocalDateTime{}, err
+	}
+	return FromInstant(u, to)
+}
+
+// BusinessDay 返回时刻 t 在 loc 中所属的营业日，营业日从每天的 cutoff（距零点的时长）开始，
+// 如 cutoff 为 4 小时时，03:59 归入前一天，04:00 归入当天。
+func BusinessDay(t time.Time, loc *time.Location, cutoff time.Duration) types.LocalDate {
+	w := wallClock(t.In(loc)).Add(-cutoff)
+	return types.LocalDateOfYmd(w.Year(), int(w.Month()), w.Day())
+}
+
+// BusinessDayBounds 返回营业日 d 在 loc 中的起止时刻 [start, end)，便于按营业日查询；
+// 起点落在夏令时跳过或重复时段时按 DSTCompatible 处理。
+func BusinessDayBounds(d types.LocalDate, loc *time.Location, cutoff time.Duration) (start, end time.Time) {
+	g := d.ToGoTime()
+	w := time.Date(g.Year(), g.Month(), g.Day(), 0, 0, 0, 0, time.UTC).Add(cutoff)
+	start, _ = resolveWallIn(w, loc, DSTCompatible)
+	end, _ = resolveWallIn(w.AddDate(0, 0, 1), loc, DSTCompatible)
+	return start, end
+}
+
+// resolveWallIn 将 loc 中的墙上时钟 w（以 UTC 表示）按 policy 转换为时刻。
+func resolveWallIn(w time.Time, loc *time.Location, policy DSTPolicy) (time.Time, error) {
+	// 前后两天的偏移即跳变前后的偏移，时区一般不会在几天内连续跳变
+	_, before := w.Add(-48 * time.Hour).In(loc).Zone()
+	_, after := w.Add(48 * time.Hour).In(loc).Zone()
+
+	var valid []time.Time
+	for _, off := range []int{before, after} {
+		u := w.Add(-time.Duration(off) * time.Second).In(loc)
+		if wallClock(u).Equal(w) && !slices.ContainsFunc(valid, u.Equal) {
+			valid = append(valid, u)
+		}
+	}
+	slices.SortFunc(valid, func(a, b time.Time) int { return a.Compare(b) })
+
+	switch {
+	case len(valid) == 1:
+		return valid[0], nil
+	case len(valid) == 2:
+		switch policy {
+		case DSTReject:
+			return time.Time{}, fmt.Errorf("%w: %s 在 %s", ErrAmbiguousTime, w.Format(time.DateTime), loc)
+		case DSTLater:
+			return valid[1], nil
+		}
+		return valid[0], nil
+	}
+	switch policy {
+	case DSTReject:
+		return time.Time{}, fmt.Errorf("%w: %s 在 %s", ErrNonexistentTime, w.Format(time.DateTime), loc)
+	case DSTEarlier:
+		return w.Add(-time.Duration(after) * time.Second).In(loc), nil
+	}
+	return w.Add(-time.Duration(before) * time.Second).In(loc), nil
+}
+
+// wallClock 返回 t 在其所在时区的墙上时钟（以 UTC 表示）。
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
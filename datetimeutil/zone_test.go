@@ -0,0 +1,106 @@
+package datetimeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToInstant(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	// 下面用到纽约不存在的墙上时钟，time.Local 为纽约时 types.LocalDateTime 无法表示，固定为 UTC
+	setLocal(t, "UTC")
+	ny, err := LoadLocation("America/New_York")
+	req.NoError(err)
+	shanghai, err := LoadLocation("Asia/Shanghai")
+	req.NoError(err)
+
+	u, err := ToUTC(mustDT(2025, 7, 1, 9, 0, 0), shanghai)
+	req.NoError(err)
+	as.Equal(time.Date(2025, 7, 1, 1, 0, 0, 0, time.UTC), u)
+	w, err := FromInstant(u, ny)
+	req.NoError(err)
+	as.Equal(mustDT(2025, 6, 30, 21, 0, 0), w)
+
+	v, err := ConvertZone(mustDT(2025, 1, 10, 20, 0, 0), shanghai, ny)
+	req.NoError(err)
+	as.Equal(mustDT(2025, 1, 10, 7, 0, 0), v)
+
+	// 2025-03-09 02:30 不存在
+	gap := mustDT(2025, 3, 9, 2, 30, 0)
+	cases := []struct {
+		policy DSTPolicy
+		want   time.Time
+	}{
+		{DSTCompatible, time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC)},
+		{DSTLater, time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC)},
+		{DSTEarlier, time.Date(2025, 3, 9, 6, 30, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		u, err := ToUTC(gap, ny, ZoneOpts().DST(c.policy))
+		req.NoError(err)
+		as.Equal(c.want, u, c.policy)
+	}
+	_, err = ToInstant(gap, ny, ZoneOpts().DST(DSTReject))
+	as.ErrorIs(err, ErrNonexistentTime)
+
+	// 2025-11-02 01:30 出现两次
+	overlap := mustDT(2025, 11, 2, 1, 30, 0)
+	u, err = ToUTC(overlap, ny)
+	req.NoError(err)
+	as.Equal(time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC), u)
+	u, err = ToUTC(overlap, ny, ZoneOpts().DST(DSTLater))
+	req.NoError(err)
+	as.Equal(time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC), u)
+	_, err = ToInstant(overlap, ny, ZoneOpts().DST(DSTReject))
+	as.ErrorIs(err, ErrAmbiguousTime)
+
+	_, err = LoadLocation("Mars/Olympus")
+	as.Error(err)
+}
+
+func TestFromInstantLocalGap(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	shanghai, err := LoadLocation("Asia/Shanghai")
+	req.NoError(err)
+	// 修改全局 time.Local，不能 t.Parallel
+	ny := setLocal(t, "America/New_York")
+
+	// 上海 2025-03-09 02:30 在 time.Local（纽约）中不存在，无法用 types.LocalDateTime 表示
+	u := time.Date(2025, 3, 8, 18, 30, 0, 0, time.UTC)
+	_, err = FromInstant(u, shanghai)
+	as.ErrorIs(err, ErrNonexistentTime)
+	_, err = ConvertZone(mustDT(2025, 3, 8, 13, 30, 0), ny, shanghai)
+	as.ErrorIs(err, ErrNonexistentTime)
+
+	w, err := FromInstant(u.Add(time.Hour), shanghai)
+	req.NoError(err)
+	as.Equal(mustDT(2025, 3, 9, 3, 30, 0), w)
+}
+
+func TestBusinessDay(t *testing.T) {
+	as := assert.New(t)
+	shanghai, err := LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	cutoff := 4 * time.Hour
+
+	// UTC 19:59 为上海 03:59，归入前一营业日
+	as.Equal(types.LocalDateOfYmd(2025, 3, 9), BusinessDay(time.Date(2025, 3, 9, 19, 59, 0, 0, time.UTC), shanghai, cutoff))
+	as.Equal(types.LocalDateOfYmd(2025, 3, 10), BusinessDay(time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC), shanghai, cutoff))
+	as.Equal(types.LocalDateOfYmd(2025, 3, 9), BusinessDay(time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC), time.UTC, 0))
+
+	start, end := BusinessDayBounds(types.LocalDateOfYmd(2025, 3, 10), shanghai, cutoff)
+	as.Equal(time.Date(2025, 3, 9, 20, 0, 0, 0, time.UTC), start.UTC())
+	as.Equal(24*time.Hour, end.Sub(start))
+
+	// 纽约 03-08 的营业日跨过夏令时开始时刻，只有 23 小时
+	ny, err := LoadLocation("America/New_York")
+	require.NoError(t, err)
+	start, end = BusinessDayBounds(types.LocalDateOfYmd(2025, 3, 8), ny, cutoff)
+	as.Equal(23*time.Hour, end.Sub(start))
+}
@@ -1,7 +1,10 @@
-// Package dateutil 提供 LocalDate 的比较与聚合工具。
+// Package dateutil 提供 LocalDate 的比较、聚合、日期区间运算、工作日历、农历与年龄、时长计算工具。
 package dateutil
 
-import "github.com/lontten/lcore/v2/types"
+import (
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
+)
 
 // Max 返回最大值，至少需要一个参数
 func Max(list ...types.LocalDate) types.LocalDate {
@@ -61,7 +64,12 @@ func MinP(list ...*types.LocalDate) *types.LocalDate {
 
 // MaxNow 从指针列表中返回最大值，以当前时间为基准，忽略nil
 func MaxNow(list ...*types.LocalDate) types.LocalDate {
-	m := types.NowDate()
+	return MaxNowWithClock(clockutil.Real, list...)
+}
+
+// MaxNowWithClock 同 MaxNow，以时钟 c 的当前时间为基准
+func MaxNowWithClock(c clockutil.Clock, list ...*types.LocalDate) types.LocalDate {
+	m := clockutil.NowDate(c)
 	for _, v := range list {
 		if v == nil {
 			continue
@@ -75,7 +83,12 @@ func MaxNow(list ...*types.LocalDate) types.LocalDate {
 
 // MinNow 从指针列表中返回最小值，以当前时间为基准，忽略nil
 func MinNow(list ...*types.LocalDate) types.LocalDate {
-	n := types.NowDate()
+	return MinNowWithClock(clockutil.Real, list...)
+}
+
+// MinNowWithClock 同 MinNow，以时钟 c 的当前时间为基准
+func MinNowWithClock(c clockutil.Clock, list ...*types.LocalDate) types.LocalDate {
+	n := clockutil.NowDate(c)
 	for _, v := range list {
 		if v == nil {
 			continue
@@ -89,7 +102,12 @@ func MinNow(list ...*types.LocalDate) types.LocalDate {
 
 // MaxNowP 从指针列表中返回最大值的指针，以当前时间为基准，忽略nil
 func MaxNowP(list ...*types.LocalDate) *types.LocalDate {
-	m := types.NowDate()
+	return MaxNowPWithClock(clockutil.Real, list...)
+}
+
+// MaxNowPWithClock 同 MaxNowP，以时钟 c 的当前时间为基准
+func MaxNowPWithClock(c clockutil.Clock, list ...*types.LocalDate) *types.LocalDate {
+	m := clockutil.NowDate(c)
 	for _, v := range list {
 		if v == nil {
 			continue
@@ -103,7 +121,12 @@ func MaxNowP(list ...*types.LocalDate) *types.LocalDate {
 
 // MinNowP 从指针列表中返回最小值的指针，以当前时间为基准，忽略nil
 func MinNowP(list ...*types.LocalDate) *types.LocalDate {
-	n := types.NowDate()
+	return MinNowPWithClock(clockutil.Real, list...)
+}
+
+// MinNowPWithClock 同 MinNowP，以时钟 c 的当前时间为基准
+func MinNowPWithClock(c clockutil.Clock, list ...*types.LocalDate) *types.LocalDate {
+	n := clockutil.NowDate(c)
 	for _, v := range list {
 		if v == nil {
 			continue
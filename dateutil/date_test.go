@@ -2,8 +2,10 @@ package dateutil
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/clockutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,3 +75,18 @@ func TestIsNowRL(t *testing.T) {
 	as.True(IsNowR(&future))
 	as.True(IsNowL(&past))
 }
+
+func TestNowWithClock(t *testing.T) {
+	as := assert.New(t)
+	clock := clockutil.NewFake(time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local))
+	d := mustDate(2025, 6, 2)
+
+	as.True(IsNowRWithClock(clock, &d))
+	as.Equal(mustDate(2025, 6, 1), MinNowWithClock(clock, &d))
+	as.Equal(d, *MaxNowPWithClock(clock, &d, nil))
+
+	clock.Advance(48 * time.Hour)
+	as.True(IsNowLWithClock(clock, &d))
+	as.Equal(mustDate(2025, 6, 3), MaxNowWithClock(clock, &d))
+	as.Equal(d, *MinNowPWithClock(clock, &d))
+}
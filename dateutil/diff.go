@@ -0,0 +1,166 @@
+package dateutil
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// Period 两个日期之间以年、月、日表示的差，各分量同号。
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// Diff 返回从 a 到 b 的年月日差，b 早于 a 时各分量为负。
+// 先取整月，剩余天数按 a 加上整月后（日超出时取月末）到 b 计算，
+// 如 2024-01-31 到 2024-03-01 为 1 个月 1 天（2024-02-29 起算）。
+// 可用于精确年龄、工龄、会员时长等。
+func Diff(a, b types.LocalDate) Period {
+	if b.Before(a) {
+		return Diff(b, a).Negate()
+	}
+	ay, am, ad := ymd(a)
+	by, bm, bd := ymd(b)
+	months := (by*12 + bm) - (ay*12 + am)
+	days := bd - ad
+	if days < 0 {
+		months--
+		days = int(epochDay(b) - epochDay(ofYmdClamped(ay, am+months, ad)))
+	}
+	return Period{Years: months / 12, Months: months % 12, Days: days}
+}
+
+// IsZero 判断是否为零差。
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Negate 返回各分量取反后的差。
+func (p Period) Negate() Period {
+	return Period{Years: -p.Years, Months: -p.Months, Days: -p.Days}
+}
+
+// AddTo 返回 d 加上该差后的日期，先加年月（日超出时取月末）再加天。
+func (p Period) AddTo(d types.LocalDate) types.LocalDate {
+	y, m, dd := ymd(d)
+	return addDays(ofYmdClamped(y, m+p.Years*12+p.Months, dd), p.Days)
+}
+
+// String 返回 ISO 8601 形式，如 P1Y2M3D、P0D，为负时如 -P1Y2M。
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	var b strings.Builder
+	q := p
+	if p.Years < 0 || p.Months < 0 || p.Days < 0 {
+		b.WriteByte('-')
+		q = p.Negate()
+	}
+	b.WriteByte('P')
+	for _, part := range []struct {
+		n    int
+		unit byte
+	}{{q.Years, 'Y'}, {q.Months, 'M'}, {q.Days, 'D'}} {
+		if part.n != 0 {
+			b.WriteString(strconv.Itoa(part.n))
+			b.WriteByte(part.unit)
+		}
+	}
+	return b.String()
+}
+
+// Chinese 返回中文形式，如 1年2个月3天，省略为零的分量，零差为 0天，为负时前缀 -。
+func (p Period) Chinese() string {
+	if p.IsZero() {
+		return "0天"
+	}
+	var b strings.Builder
+	q := p
+	if p.Years < 0 || p.Months < 0 || p.Days < 0 {
+		b.WriteByte('-')
+		q = p.Negate()
+	}
+	for _, part := range []struct {
+		n    int
+		unit string
+	}{{q.Years, "年"}, {q.Months, "个月"}, {q.Days, "天"}} {
+		if part.n != 0 {
+			b.WriteString(strconv.Itoa(part.n))
+			b.WriteString(part.unit)
+		}
+	}
+	return b.String()
+}
+
+// LeapDayPolicy 2 月 29 日的纪念日在平年的处理方式。
+type LeapDayPolicy int
+
+const (
+	LeapDayFeb28 LeapDayPolicy = iota // 平年取 2 月 28 日
+	LeapDayMar1                       // 平年取 3 月 1 日
+)
+
+type anniversaryOpts struct {
+	leapDay LeapDayPolicy
+}
+
+// AnniversaryOpts 创建纪念日选项，默认 2 月 29 日在平年取 2 月 28 日。
+func AnniversaryOpts() *anniversaryOpts {
+	return &anniversaryOpts{leapDay: LeapDayFeb28}
+}
+
+// LeapDay 设置 2 月 29 日在平年的处理方式。
+func (o *anniversaryOpts) LeapDay(p LeapDayPolicy) *anniversaryOpts {
+	o.leapDay = p
+	return o
+}
+
+func resolveAnniversaryOpts(opts ...*anniversaryOpts) *anniversaryOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return AnniversaryOpts()
+	}
+	return opts[0]
+}
+
+// Age 返回 birth 出生者在 on 当天的周岁，生日当天增加一岁；on 早于 birth 时返回 0。
+func Age(birth, on types.LocalDate, opts ...*anniversaryOpts) int {
+	o := resolveAnniversaryOpts(opts...)
+	if on.Before(birth) {
+		return 0
+	}
+	by, _, _ := ymd(birth)
+	oy, _, _ := ymd(on)
+	age := oy - by
+	if on.Before(anniversaryIn(birth, oy, o)) {
+		age--
+	}
+	return age
+}
+
+// NextAnniversary 返回 d 在 on 当天或之后的第一个周年纪念日及其周年数，如下一个生日及届时的周岁。
+// on 不晚于 d 时返回一周年。
+func NextAnniversary(d, on types.LocalDate, opts ...*anniversaryOpts) (types.LocalDate, int) {
+	o := resolveAnniversaryOpts(opts...)
+	dy, _, _ := ymd(d)
+	oy, _, _ := ymd(on)
+	y := max(oy, dy+1)
+	next := anniversaryIn(d, y, o)
+	if next.Before(on) {
+		y++
+		next = anniversaryIn(d, y, o)
+	}
+	return next, y - dy
+}
+
+// anniversaryIn 返回 d 在 year 年的纪念日。
+func anniversaryIn(d types.LocalDate, year int, o *anniversaryOpts) types.LocalDate {
+	_, m, dd := ymd(d)
+	if m == 2 && dd == 29 && daysIn(year, 2) == 28 && o.leapDay == LeapDayMar1 {
+		return types.LocalDateOfYmd(year, 3, 1)
+	}
+	return ofYmdClamped(year, m, dd)
+}
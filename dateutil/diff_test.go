@@ -0,0 +1,74 @@
+package dateutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	as := assert.New(t)
+	cases := []struct {
+		a, b [3]int
+		want Period
+		iso  string
+		zh   string
+	}{
+		{[3]int{2023, 1, 15}, [3]int{2024, 3, 18}, Period{1, 2, 3}, "P1Y2M3D", "1年2个月3天"},
+		{[3]int{2024, 1, 31}, [3]int{2024, 3, 1}, Period{0, 1, 1}, "P1M1D", "1个月1天"},
+		{[3]int{2024, 1, 31}, [3]int{2024, 2, 29}, Period{0, 0, 29}, "P29D", "29天"},
+		{[3]int{2024, 2, 29}, [3]int{2025, 2, 28}, Period{0, 11, 30}, "P11M30D", "11个月30天"},
+		{[3]int{2024, 5, 1}, [3]int{2024, 5, 1}, Period{}, "P0D", "0天"},
+		{[3]int{2024, 3, 18}, [3]int{2023, 1, 15}, Period{-1, -2, -3}, "-P1Y2M3D", "-1年2个月3天"},
+	}
+	for _, c := range cases {
+		a, b := mustDate(c.a[0], c.a[1], c.a[2]), mustDate(c.b[0], c.b[1], c.b[2])
+		p := Diff(a, b)
+		as.Equal(c.want, p, c.iso)
+		as.Equal(c.iso, p.String())
+		as.Equal(c.zh, p.Chinese())
+		if !p.IsZero() && p.Years >= 0 && c.a[2] <= 28 {
+			as.Equal(b, p.AddTo(a), c.iso)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	as := assert.New(t)
+	birth := mustDate(1990, 6, 15)
+	as.Equal(34, Age(birth, mustDate(2025, 6, 14)))
+	as.Equal(35, Age(birth, mustDate(2025, 6, 15)))
+	as.Equal(0, Age(birth, mustDate(1980, 1, 1)))
+
+	leap := mustDate(2000, 2, 29)
+	as.Equal(24, Age(leap, mustDate(2025, 2, 27)))
+	as.Equal(25, Age(leap, mustDate(2025, 2, 28)))
+	mar1 := AnniversaryOpts().LeapDay(LeapDayMar1)
+	as.Equal(24, Age(leap, mustDate(2025, 2, 28), mar1))
+	as.Equal(25, Age(leap, mustDate(2025, 3, 1), mar1))
+	as.Equal(24, Age(leap, mustDate(2024, 2, 29), mar1))
+}
+
+func TestNextAnniversary(t *testing.T) {
+	as := assert.New(t)
+	birth := mustDate(1990, 6, 15)
+
+	next, n := NextAnniversary(birth, mustDate(2025, 6, 15))
+	as.Equal(mustDate(2025, 6, 15), next)
+	as.Equal(35, n)
+	next, n = NextAnniversary(birth, mustDate(2025, 6, 16))
+	as.Equal(mustDate(2026, 6, 15), next)
+	as.Equal(36, n)
+	next, n = NextAnniversary(birth, mustDate(1989, 1, 1))
+	as.Equal(mustDate(1991, 6, 15), next)
+	as.Equal(1, n)
+
+	leap := mustDate(2000, 2, 29)
+	next, _ = NextAnniversary(leap, mustDate(2025, 1, 1))
+	as.Equal(mustDate(2025, 2, 28), next)
+	next, _ = NextAnniversary(leap, mustDate(2025, 1, 1), AnniversaryOpts().LeapDay(LeapDayMar1))
+	as.Equal(mustDate(2025, 3, 1), next)
+	next, n = NextAnniversary(leap, mustDate(2027, 3, 2))
+	as.Equal(mustDate(2028, 2, 29), next)
+	as.Equal(28, n)
+}
@@ -0,0 +1,167 @@
+//go:build ignore
+
+// Generate data/workcalendar/<year>.json from the State Council holiday notice text.
+//
+// 用法：将国务院办公厅《关于 XXXX 年部分节假日安排的通知》正文中的各条安排
+// （形如「二、春节：1月28日（农历除夕、周二）至2月4日（农历正月初七、周二）放假调休，共8天。
+// 1月26日（周日）、2月8日（周六）上班。」）逐行保存为文本文件，然后执行：
+//
+//	go run gen_workcalendar.go 2025 notice.txt "国办发明电〔2024〕12号"
+//
+// 未写年份的日期默认属于 <year>；「至」后省略月份时沿用前一个日期的月份。
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type holidayEntry struct {
+	Name     string   `json:"name"`
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Workdays []string `json:"workdays"`
+}
+
+type yearFile struct {
+	Year     int            `json:"year"`
+	Source   string         `json:"source,omitempty"`
+	Holidays []holidayEntry `json:"holidays"`
+}
+
+var (
+	prefixRe = regexp.MustCompile(`^[一二三四五六七八九十]+[、.．]\s*`)
+	parenRe  = regexp.MustCompile(`（[^）]*）|\([^)]*\)`)
+	dateRe   = regexp.MustCompile(`(?:(\d{4})年)?(?:(\d{1,2})月)?(\d{1,2})日`)
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "usage: go run gen_workcalendar.go <year> <notice.txt> [source]")
+		os.Exit(1)
+	}
+	year, err := strconv.Atoi(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid year %q\n", os.Args[1])
+		os.Exit(1)
+	}
+	f, err := os.Open(os.Args[2])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "open %s: %v\n", os.Args[2], err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	out := yearFile{Year: year}
+	if len(os.Args) > 3 {
+		out.Source = os.Args[3]
+	}
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		entry, err := parseLine(year, line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "parse %q: %v\n", line, err)
+			os.Exit(1)
+		}
+		out.Holidays = append(out.Holidays, entry)
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "read: %v\n", err)
+		os.Exit(1)
+	}
+
+	raw, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "marshal: %v\n", err)
+		os.Exit(1)
+	}
+	outPath := filepath.Join("data", "workcalendar", fmt.Sprintf("%d.json", year))
+	if err := os.WriteFile(outPath, append(raw, '\n'), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "write %s: %v\n", outPath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("generated %d holidays -> %s\n", len(out.Holidays), outPath)
+}
+
+func parseLine(year int, line string) (holidayEntry, error) {
+	line = prefixRe.ReplaceAllString(line, "")
+	name, body, ok := strings.Cut(line, "：")
+	if !ok {
+		name, body, ok = strings.Cut(line, ":")
+	}
+	if !ok {
+		return holidayEntry{}, fmt.Errorf("缺少节日名称")
+	}
+	body = parenRe.ReplaceAllString(body, "")
+
+	rangePart, rest, ok := strings.Cut(body, "放假")
+	if !ok {
+		return holidayEntry{}, fmt.Errorf("缺少「放假」")
+	}
+	dates, err := extractDates(year, rangePart)
+	if err != nil {
+		return holidayEntry{}, err
+	}
+	if len(dates) == 0 || len(dates) > 2 {
+		return holidayEntry{}, fmt.Errorf("放假日期数量非法: %d", len(dates))
+	}
+	entry := holidayEntry{Name: strings.TrimSpace(name), Start: dates[0], End: dates[len(dates)-1], Workdays: []string{}}
+	if entry.End < entry.Start {
+		return holidayEntry{}, fmt.Errorf("结束日期早于开始日期")
+	}
+
+	for _, sentence := range strings.Split(rest, "。") {
+		if !strings.Contains(sentence, "上班") {
+			continue
+		}
+		workdays, err := extractDates(year, sentence)
+		if err != nil {
+			return holidayEntry{}, err
+		}
+		for _, d := range workdays {
+			t, _ := time.Parse(time.DateOnly, d)
+			if wd := t.Weekday(); wd != time.Saturday && wd != time.Sunday {
+				return holidayEntry{}, fmt.Errorf("调休上班日 %s 不是周末", d)
+			}
+		}
+		entry.Workdays = append(entry.Workdays, workdays...)
+	}
+	return entry, nil
+}
+
+func extractDates(year int, s string) ([]string, error) {
+	var out []string
+	month := 0
+	for _, m := range dateRe.FindAllStringSubmatch(s, -1) {
+		y := year
+		if m[1] != "" {
+			y, _ = strconv.Atoi(m[1])
+		}
+		if m[2] != "" {
+			month, _ = strconv.Atoi(m[2])
+		}
+		if month == 0 {
+			return nil, fmt.Errorf("日期缺少月份: %q", m[0])
+		}
+		day, _ := strconv.Atoi(m[3])
+		t := time.Date(y, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if t.Day() != day || int(t.Month()) != month {
+			return nil, fmt.Errorf("非法日期: %q", m[0])
+		}
+		out = append(out, t.Format(time.DateOnly))
+	}
+	return out, nil
+}
@@ -0,0 +1,307 @@
+package dateutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// ErrLunarOutOfRange 日期超出农历数据覆盖范围（公历 1900-01-31 ~ 2100-12-31）。
+var ErrLunarOutOfRange = errors.New("日期超出农历数据范围 1900~2100")
+
+var (
+	heavenlyStems   = [10]string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	earthlyBranches = [12]string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	zodiacNames     = [12]string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+	lunarMonthNames = [13]string{"", "正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	lunarDayTens    = [4]string{"初", "十", "廿", "三"}
+	chineseDigits   = [10]string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+)
+
+const (
+	lunarMinYear = 1900
+	lunarMaxYear = 2100
+)
+
+// lunarBaseDay 农历 1900 年正月初一（公历 1900-01-31）的 epochDay。
+var lunarBaseDay = epochDay(types.LocalDateOfYmd(1900, 1, 31))
+
+// lunarMaxDay 支持的最大公历日期 2100-12-31 的 epochDay。
+var lunarMaxDay = epochDay(types.LocalDateOfYmd(2100, 12, 31))
+
+// LunarDate 农历日期。
+type LunarDate struct {
+	Year   int  // 农历年，以正月初一为界
+	Month  int  // 月，1~12
+	Day    int  // 日，1~30
+	IsLeap bool // 是否闰月
+}
+
+// SolarToLunar 公历转农历，支持 1900-01-31 ~ 2100-12-31。
+func SolarToLunar(d types.LocalDate) (LunarDate, error) {
+	n := epochDay(d)
+	if n < lunarBaseDay || n > lunarMaxDay {
+		return LunarDate{}, fmt.Errorf("%w: %s", ErrLunarOutOfRange, d)
+	}
+	offset := int(n - lunarBaseDay)
+
+	year := lunarMinYear
+	for ; year <= lunarMaxYear; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+
+	leap := LunarLeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		days := LunarMonthDays(year, month, false)
+		if offset < days {
+			return LunarDate{Year: year, Month: month, Day: offset + 1}, nil
+		}
+		offset -= days
+		if month == leap {
+			days = LunarMonthDays(year, month, true)
+			if offset < days {
+				return LunarDate{Year: year, Month: month, Day: offset + 1, IsLeap: true}, nil
+			}
+			offset -= days
+		}
+	}
+	// 农历 2100 年末超出公历上限前已返回，此处不可达
+	return LunarDate{}, fmt.Errorf("%w: %s", ErrLunarOutOfRange, d)
+}
+
+// MustSolarToLunar 同 SolarToLunar，超出范围时 panic。
+func MustSolarToLunar(d types.LocalDate) LunarDate {
+	l, err := SolarToLunar(d)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// LunarToSolar 农历转公历。月、日、闰月不存在时返回错误。
+func LunarToSolar(l LunarDate) (types.LocalDate, error) {
+	if l.Year < lunarMinYear || l.Year > lunarMaxYear {
+		return types.LocalDate{}, fmt.Errorf("%w: 农历 %d 年", ErrLunarOutOfRange, l.Year)
+	}
+	if l.Month < 1 || l.Month > 12 {
+		return types.LocalDate{}, fmt.Errorf("农历月份非法: %d", l.Month)
+	}
+	leap := LunarLeapMonth(l.Year)
+	if l.IsLeap && leap != l.Month {
+		return types.LocalDate{}, fmt.Errorf("农历 %d 年没有闰%s月", l.Year, lunarMonthNames[l.Month])
+	}
+	if l.Day < 1 || l.Day > LunarMonthDays(l.Year, l.Month, l.IsLeap) {
+		return types.LocalDate{}, fmt.Errorf("农历日期非法: %s", l)
+	}
+
+	n := lunarBaseDay
+	for y := lunarMinYear; y < l.Year; y++ {
+		n += int64(lunarYearDays(y))
+	}
+	for m := 1; m < l.Month; m++ {
+		n += int64(LunarMonthDays(l.Year, m, false))
+		if m == leap {
+			n += int64(LunarMonthDays(l.Year, m, true))
+		}
+	}
+	if l.IsLeap {
+		n += int64(LunarMonthDays(l.Year, l.Month, false))
+	}
+	n += int64(l.Day - 1)
+	if n > lunarMaxDay {
+		return types.LocalDate{}, fmt.Errorf("%w: %s", ErrLunarOutOfRange, l)
+	}
+	return ofEpochDay(n), nil
+}
+
+// LunarLeapMonth 返回农历某年的闰月月份，无闰月返回 0。
+func LunarLeapMonth(year int) int {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return 0
+	}
+	return int(lunarInfo[year-lunarMinYear] & 0xf)
+}
+
+// LunarMonthDays 返回农历某年某月（leap 为 true 时为闰月）的天数，不存在时返回 0。
+func LunarMonthDays(year, month int, leap bool) int {
+	if year < lunarMinYear || year > lunarMaxYear || month < 1 || month > 12 {
+		return 0
+	}
+	info := lunarInfo[year-lunarMinYear]
+	if leap {
+		if int(info&0xf) != month {
+			return 0
+		}
+		if info&0x10000 != 0 {
+			return 30
+		}
+		return 29
+	}
+	if info&(0x10000>>month) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarYearDays 返回农历某年总天数（含闰月）。
+func lunarYearDays(year int) int {
+	days := 0
+	for m := 1; m <= 12; m++ {
+		days += LunarMonthDays(year, m, false)
+	}
+	if leap := LunarLeapMonth(year); leap > 0 {
+		days += LunarMonthDays(year, leap, true)
+	}
+	return days
+}
+
+// YearGanZhi 返回农历年的干支纪年，如「乙巳」。
+func (l LunarDate) YearGanZhi() string {
+	return ganZhi(l.Year - 4)
+}
+
+// Zodiac 返回农历年的生肖，如「蛇」。
+func (l LunarDate) Zodiac() string {
+	return zodiacNames[mod(l.Year-4, 12)]
+}
+
+// MonthName 返回月份名，如「正月」「闰六月」「腊月」。
+func (l LunarDate) MonthName() string {
+	name := lunarMonthNames[l.Month] + "月"
+	if l.IsLeap {
+		return "闰" + name
+	}
+	return name
+}
+
+// DayName 返回日名，如「初一」「十五」「廿三」「三十」。
+func (l LunarDate) DayName() string {
+	switch l.Day {
+	case 10:
+		return "初十"
+	case 20:
+		return "二十"
+	case 30:
+		return "三十"
+	}
+	return lunarDayTens[l.Day/10] + chineseDigits[l.Day%10]
+}
+
+// String 返回「二〇二五年闰六月初一」形式的农历日期。
+func (l LunarDate) String() string {
+	var sb strings.Builder
+	for _, c := range fmt.Sprint(l.Year) {
+		sb.WriteString(chineseDigits[c-'0'])
+	}
+	sb.WriteString("年")
+	sb.WriteString(l.MonthName())
+	sb.WriteString(l.DayName())
+	return sb.String()
+}
+
+// GanZhiDate 四柱中的年、月、日干支。
+type GanZhiDate struct {
+	Year  string // 年柱，以立春为界
+	Month string // 月柱，以节（立春、惊蛰……）为界
+	Day   string // 日柱
+}
+
+// GanZhiOf 返回公历日期的年、月、日干支（八字前三柱），支持 1900~2100 年。
+// 与 LunarDate.YearGanZhi 不同，年柱以立春而非正月初一为界。
+func GanZhiOf(d types.LocalDate) (GanZhiDate, error) {
+	year, _, _ := ymd(d)
+	days, ok := solarTermDays(year)
+	if !ok {
+		return GanZhiDate{}, fmt.Errorf("%w: %s", ErrLunarOutOfRange, d)
+	}
+	n := epochDay(d)
+
+	// 节为偶数下标：小寒(0)、立春(2)、惊蛰(4)……；monthIdx 0 表示寅月
+	gzYear := year
+	if n < days[2] {
+		gzYear--
+	}
+	monthIdx := 10 // 小寒之前为子月
+	for i := 0; i < 24; i += 2 {
+		if n >= days[i] {
+			monthIdx = mod(i/2-1, 12)
+		}
+	}
+
+	yearStem := mod(gzYear-4, 10)
+	monthStem := (yearStem%5*2 + 2 + monthIdx) % 10
+	monthBranch := (2 + monthIdx) % 12
+	return GanZhiDate{
+		Year:  ganZhi(gzYear - 4),
+		Month: heavenlyStems[monthStem] + earthlyBranches[monthBranch],
+		// 1970-01-01 为辛巳日（序号 17）
+		Day: ganZhi(int(n%60) + 17),
+	}, nil
+}
+
+// ganZhi 返回六十甲子中第 i 个（0 为甲子）的名称，i 可为负。
+func ganZhi(i int) string {
+	return heavenlyStems[mod(i, 10)] + earthlyBranches[mod(i, 12)]
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+// lunarFestivals 农历节日（非闰月），除夕另行判断。
+var lunarFestivals = map[[2]int]string{
+	{1, 1}:   "春节",
+	{1, 15}:  "元宵节",
+	{2, 2}:   "龙抬头",
+	{5, 5}:   "端午节",
+	{7, 7}:   "七夕",
+	{7, 15}:  "中元节",
+	{8, 15}:  "中秋节",
+	{9, 9}:   "重阳节",
+	{12, 8}:  "腊八节",
+	{12, 23}: "小年",
+}
+
+// solarFestivals 公历节日。
+var solarFestivals = map[[2]int]string{
+	{1, 1}:   "元旦",
+	{2, 14}:  "情人节",
+	{3, 8}:   "妇女节",
+	{3, 12}:  "植树节",
+	{5, 1}:   "劳动节",
+	{5, 4}:   "青年节",
+	{6, 1}:   "儿童节",
+	{7, 1}:   "建党节",
+	{8, 1}:   "建军节",
+	{9, 10}:  "教师节",
+	{10, 1}:  "国庆节",
+	{12, 25}: "圣诞节",
+}
+
+// Festivals 返回某天的节日：依次为农历节日（含除夕）、清明节、公历节日；无节日返回 nil。
+// 超出农历数据范围时仅返回公历节日。
+func Festivals(d types.LocalDate) []string {
+	var out []string
+	if l, err := SolarToLunar(d); err == nil && !l.IsLeap {
+		if name, ok := lunarFestivals[[2]int{l.Month, l.Day}]; ok {
+			out = append(out, name)
+		}
+		if l.Month == 12 && l.Day == LunarMonthDays(l.Year, 12, false) {
+			out = append(out, "除夕")
+		}
+	}
+	if term, ok := SolarTermOn(d); ok && term == "清明" {
+		out = append(out, "清明节")
+	}
+	_, m, day := ymd(d)
+	if name, ok := solarFestivals[[2]int{m, day}]; ok {
+		out = append(out, name)
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package dateutil
+
+// lunarInfo 1900~2100 年农历数据，每年一个值：
+//   - bit 0~3：闰月月份，0 表示无闰月
+//   - bit 4~15：正月至腊月大小月，0x8000 对应正月，1 为大月（30 天），0 为小月（29 天）
+//   - bit 16：闰月为大月时置 1
+var lunarInfo = [...]uint32{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050-2059
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090-2099
+	0x0d520, // 2100
+}
+
+// earthL0~earthL5 VSOP87 地球日心黄经截断级数（Meeus《天文算法》表 32.A），
+// 每项为 A、B、C，值为 A·cos(B + C·τ)，τ 为自 J2000 起的儒略千年数。
+var earthL0 = [][3]float64{
+	{175347046, 0, 0}, {3341656, 4.6692568, 6283.07585}, {34894, 4.6261, 12566.1517},
+	{3497, 2.7441, 5753.3849}, {3418, 2.8289, 3.5231}, {3136, 3.6277, 77713.7715},
+	{2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097}, {1324, 0.7425, 11506.7698},
+	{1273, 2.0371, 529.691}, {1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927},
+	{902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694},
+	{753, 2.533, 5507.553}, {505, 4.583, 18849.228}, {492, 4.205, 775.523},
+	{357, 2.92, 0.067}, {317, 5.849, 11790.629}, {284, 1.899, 796.298},
+	{271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314},
+	{205, 1.869, 5573.143}, {202, 2.458, 6069.777}, {156, 0.833, 213.299},
+	{132, 3.411, 2942.463}, {126, 1.083, 20.775}, {115, 0.645, 0.98},
+	{103, 0.636, 4694.003}, {102, 0.976, 15720.839}, {102, 4.267, 7.114},
+	{99, 6.21, 2146.17}, {98, 0.68, 155.42}, {86, 5.98, 161000.69},
+	{85, 1.3, 6275.96}, {85, 3.67, 71430.7}, {80, 1.81, 17260.15},
+	{79, 3.04, 12036.46}, {75, 1.76, 5088.63}, {74, 3.5, 3154.69},
+	{74, 4.68, 801.82}, {70, 0.83, 9437.76}, {62, 3.98, 8827.39},
+	{61, 1.82, 7084.9}, {57, 2.78, 6286.6}, {56, 4.39, 14143.5},
+	{56, 3.47, 6279.55}, {52, 0.19, 12139.55}, {52, 1.33, 1748.02},
+	{51, 0.28, 5856.48}, {49, 0.49, 1194.45}, {41, 5.37, 8429.24},
+	{41, 2.4, 19651.05}, {39, 6.17, 10447.39}, {37, 6.04, 10213.29},
+	{37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+	{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87},
+	{25, 3.16, 4690.48},
+}
+
+var earthL1 = [][3]float64{
+	{628331966747, 0, 0}, {206059, 2.678235, 6283.07585}, {4303, 2.6351, 12566.1517},
+	{425, 1.59, 3.523}, {119, 5.796, 26.298}, {109, 2.966, 1577.344},
+	{93, 2.59, 18849.23}, {72, 1.14, 529.69}, {68, 1.87, 398.15},
+	{67, 4.41, 5507.55}, {59, 2.89, 5223.69}, {56, 2.17, 155.42},
+	{45, 0.4, 796.3}, {36, 0.47, 775.52}, {29, 2.65, 7.11},
+	{21, 5.34, 0.98}, {19, 1.85, 5486.78}, {19, 4.97, 213.3},
+	{17, 2.99, 6275.96}, {16, 0.03, 2544.31}, {16, 1.43, 2146.17},
+	{15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63},
+	{12, 5.27, 1194.45}, {12, 2.08, 4694}, {11, 0.77, 553.57},
+	{10, 1.3, 6286.6}, {10, 4.24, 1349.87}, {9, 2.7, 242.73},
+	{9, 5.64, 951.72}, {8, 5.3, 2352.87}, {6, 2.65, 9437.76},
+	{6, 4.67, 4690.48},
+}
+
+var earthL2 = [][3]float64{
+	{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152},
+	{27, 0.05, 3.52}, {16, 5.19, 26.3}, {16, 3.68, 155.42},
+	{10, 0.76, 18849.23}, {9, 2.06, 77713.77}, {7, 0.83, 775.52},
+	{5, 4.66, 1577.34}, {4, 1.03, 7.11}, {4, 3.44, 5573.14},
+	{3, 5.14, 796.3}, {3, 6.05, 5507.55}, {3, 1.19, 242.73},
+	{3, 6.12, 529.69}, {3, 0.31, 398.15}, {3, 2.28, 553.57},
+	{2, 4.38, 5223.69}, {2, 3.75, 0.98},
+}
+
+var earthL3 = [][3]float64{
+	{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15},
+	{3, 5.2, 155.42}, {1, 4.72, 3.52}, {1, 5.3, 18849.23},
+	{1, 5.97, 242.73},
+}
+
+var earthL4 = [][3]float64{
+	{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+}
+
+var earthL5 = [][3]float64{
+	{1, 3.14, 0},
+}
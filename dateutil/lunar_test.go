@@ -0,0 +1,115 @@
+package dateutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolarToLunar(t *testing.T) {
+	as := assert.New(t)
+	// 春节日期
+	for _, d := range [][3]int{
+		{1900, 1, 31}, {1949, 1, 29}, {2000, 2, 5}, {2020, 1, 25}, {2024, 2, 10}, {2025, 1, 29}, {2026, 2, 17},
+	} {
+		l := MustSolarToLunar(mustDate(d[0], d[1], d[2]))
+		as.Equal(LunarDate{Year: d[0], Month: 1, Day: 1}, l, d)
+	}
+
+	// 2025 年闰六月
+	as.Equal(6, LunarLeapMonth(2025))
+	l := MustSolarToLunar(mustDate(2025, 7, 25))
+	as.Equal(LunarDate{Year: 2025, Month: 6, Day: 1, IsLeap: true}, l)
+	as.Equal("二〇二五年闰六月初一", l.String())
+	as.Equal("乙巳", l.YearGanZhi())
+	as.Equal("蛇", l.Zodiac())
+
+	// 正月初一前仍属上一农历年
+	l = MustSolarToLunar(mustDate(2025, 1, 28))
+	as.Equal(LunarDate{Year: 2024, Month: 12, Day: 29}, l)
+	as.Equal("腊月廿九", l.MonthName()+l.DayName())
+	as.Equal("龙", l.Zodiac())
+
+	_, err := SolarToLunar(mustDate(1900, 1, 30))
+	as.ErrorIs(err, ErrLunarOutOfRange)
+	_, err = SolarToLunar(mustDate(2101, 1, 1))
+	as.ErrorIs(err, ErrLunarOutOfRange)
+}
+
+func TestLunarToSolar(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+
+	d, err := LunarToSolar(LunarDate{Year: 2025, Month: 8, Day: 15})
+	req.NoError(err)
+	as.Equal(mustDate(2025, 10, 6), d)
+
+	d, err = LunarToSolar(LunarDate{Year: 2025, Month: 6, Day: 1, IsLeap: true})
+	req.NoError(err)
+	as.Equal(mustDate(2025, 7, 25), d)
+
+	_, err = LunarToSolar(LunarDate{Year: 2024, Month: 6, Day: 1, IsLeap: true})
+	as.Error(err, "2024 年无闰六月")
+	_, err = LunarToSolar(LunarDate{Year: 2025, Month: 13, Day: 1})
+	as.Error(err)
+	_, err = LunarToSolar(LunarDate{Year: 2025, Month: 1, Day: 31})
+	as.Error(err)
+
+	// 全范围往返
+	for n := lunarBaseDay; n <= lunarMaxDay; n += 7 {
+		l := MustSolarToLunar(ofEpochDay(n))
+		back, err := LunarToSolar(l)
+		req.NoError(err)
+		req.Equal(n, epochDay(back), l.String())
+	}
+}
+
+func TestSolarTerms(t *testing.T) {
+	as := assert.New(t)
+	terms := SolarTermsOf(2025)
+	as.Len(terms, 24)
+	as.Equal(SolarTerm{Index: 2, Name: "立春", Date: mustDate(2025, 2, 3)}, terms[2])
+	as.Equal(mustDate(2025, 4, 4), terms[6].Date)
+	as.Equal(mustDate(2025, 12, 21), terms[23].Date)
+
+	// 2021 年冬至交节于北京时间 12-21 23:59
+	name, ok := SolarTermOn(mustDate(2021, 12, 21))
+	as.True(ok)
+	as.Equal("冬至", name)
+	_, ok = SolarTermOn(mustDate(2021, 12, 22))
+	as.False(ok)
+
+	as.Nil(SolarTermsOf(1899))
+}
+
+func TestGanZhiOf(t *testing.T) {
+	as := assert.New(t)
+	g, err := GanZhiOf(mustDate(2000, 1, 1))
+	as.NoError(err)
+	as.Equal(GanZhiDate{Year: "己卯", Month: "丙子", Day: "戊午"}, g)
+
+	// 2025 年立春为 2-3：之前仍为甲辰年丁丑月
+	g, _ = GanZhiOf(mustDate(2025, 2, 2))
+	as.Equal("甲辰", g.Year)
+	as.Equal("丁丑", g.Month)
+	g, _ = GanZhiOf(mustDate(2025, 2, 3))
+	as.Equal("乙巳", g.Year)
+	as.Equal("戊寅", g.Month)
+
+	_, err = GanZhiOf(mustDate(2101, 1, 1))
+	as.ErrorIs(err, ErrLunarOutOfRange)
+}
+
+func TestFestivals(t *testing.T) {
+	as := assert.New(t)
+	as.Equal([]string{"春节"}, Festivals(mustDate(2026, 2, 17)))
+	as.Equal([]string{"除夕"}, Festivals(mustDate(2026, 2, 16)))
+	as.Equal([]string{"端午节"}, Festivals(mustDate(2025, 5, 31)))
+	as.Equal([]string{"中秋节"}, Festivals(mustDate(2025, 10, 6)))
+	as.Equal([]string{"清明节"}, Festivals(mustDate(2025, 4, 4)))
+	as.Equal([]string{"国庆节"}, Festivals(mustDate(2025, 10, 1)))
+	as.Nil(Festivals(mustDate(2025, 10, 18)))
+	// 闰月不重复过节：2025 闰六月初七不是七夕
+	as.Nil(Festivals(mustDate(2025, 7, 31)))
+}
@@ -0,0 +1,106 @@
+package dateutil
+
+import (
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// PeriodUnit 周期单位，用于 StartOf / EndOf。
+type PeriodUnit int
+
+const (
+	PeriodDay      PeriodUnit = iota // 日
+	PeriodWeek                       // 周，起始日由 PeriodOpts().WeekStart 指定，默认周一（ISO）
+	PeriodMonth                      // 月
+	PeriodQuarter                    // 季度
+	PeriodHalfYear                   // 半年
+	PeriodYear                       // 年
+)
+
+type periodOpts struct {
+	weekStart time.Weekday
+}
+
+// PeriodOpts 创建周期选项，默认一周从周一开始。
+func PeriodOpts() *periodOpts {
+	return &periodOpts{weekStart: time.Monday}
+}
+
+// WeekStart 设置一周的起始日，如美式日历 time.Sunday。
+func (o *periodOpts) WeekStart(d time.Weekday) *periodOpts {
+	o.weekStart = d
+	return o
+}
+
+func resolvePeriodOpts(opts ...*periodOpts) *periodOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return PeriodOpts()
+	}
+	return opts[0]
+}
+
+// StartOf 返回 d 所在周期的第一天，如 StartOf(d, PeriodQuarter) 为本季度第一天。
+func StartOf(d types.LocalDate, unit PeriodUnit, opts ...*periodOpts) types.LocalDate {
+	o := resolvePeriodOpts(opts...)
+	y, m, _ := ymd(d)
+	switch unit {
+	case PeriodDay:
+		return d
+	case PeriodWeek:
+		back := (int(weekday(d)) - int(o.weekStart) + 7) % 7
+		return addDays(d, -back)
+	case PeriodMonth:
+		return types.LocalDateOfYmd(y, m, 1)
+	case PeriodQuarter:
+		return types.LocalDateOfYmd(y, (m-1)/3*3+1, 1)
+	case PeriodHalfYear:
+		return types.LocalDateOfYmd(y, (m-1)/6*6+1, 1)
+	case PeriodYear:
+		return types.LocalDateOfYmd(y, 1, 1)
+	}
+	panic("dateutil.StartOf: unknown period unit")
+}
+
+// EndOf 返回 d 所在周期的最后一天，如 EndOf(AddMonthsClamped(d, -1), PeriodMonth) 为上月最后一天。
+func EndOf(d types.LocalDate, unit PeriodUnit, opts ...*periodOpts) types.LocalDate {
+	start := StartOf(d, unit, opts...)
+	y, m, _ := ymd(start)
+	switch unit {
+	case PeriodDay:
+		return d
+	case PeriodWeek:
+		return addDays(start, 6)
+	case PeriodMonth:
+		return types.LocalDateOfYmd(y, m, daysIn(y, m))
+	case PeriodQuarter:
+		return types.LocalDateOfYmd(y, m+2, daysIn(y, m+2))
+	case PeriodHalfYear:
+		return types.LocalDateOfYmd(y, m+5, daysIn(y, m+5))
+	default:
+		return types.LocalDateOfYmd(y, 12, 31)
+	}
+}
+
+// PeriodOf 返回 d 所在周期的闭区间 [StartOf, EndOf]。
+func PeriodOf(d types.LocalDate, unit PeriodUnit, opts ...*periodOpts) DateRange {
+	return NewDateRange(StartOf(d, unit, opts...), EndOf(d, unit, opts...))
+}
+
+// AddMonthsClamped 加减 n 个月，目标月没有对应日时取月末，如 01-31 加一个月为 02-28（闰年 02-29）。
+func AddMonthsClamped(d types.LocalDate, n int) types.LocalDate {
+	y, m, day := ymd(d)
+	return ofYmdClamped(y, m+n, day)
+}
+
+// ISOWeek 返回 ISO 8601 周数及其所属年份；年初几天可能属于上一年的最后一周。
+func ISOWeek(d types.LocalDate) (year, week int) {
+	y, m, day := ymd(d)
+	return time.Date(y, time.Month(m), day, 0, 0, 0, 0, time.UTC).ISOWeek()
+}
+
+// Quarter 返回 d 所在的季度，1~4。
+func Quarter(d types.LocalDate) int {
+	_, m, _ := ymd(d)
+	return (m-1)/3 + 1
+}
@@ -0,0 +1,59 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartOfEndOf(t *testing.T) {
+	as := assert.New(t)
+	// 2024-08-15 为星期四
+	d := mustDate(2024, 8, 15)
+	cases := []struct {
+		unit       PeriodUnit
+		start, end [3]int
+	}{
+		{PeriodDay, [3]int{2024, 8, 15}, [3]int{2024, 8, 15}},
+		{PeriodWeek, [3]int{2024, 8, 12}, [3]int{2024, 8, 18}},
+		{PeriodMonth, [3]int{2024, 8, 1}, [3]int{2024, 8, 31}},
+		{PeriodQuarter, [3]int{2024, 7, 1}, [3]int{2024, 9, 30}},
+		{PeriodHalfYear, [3]int{2024, 7, 1}, [3]int{2024, 12, 31}},
+		{PeriodYear, [3]int{2024, 1, 1}, [3]int{2024, 12, 31}},
+	}
+	for _, c := range cases {
+		as.Equal(mustDate(c.start[0], c.start[1], c.start[2]), StartOf(d, c.unit), c.unit)
+		as.Equal(mustDate(c.end[0], c.end[1], c.end[2]), EndOf(d, c.unit), c.unit)
+	}
+
+	sunday := PeriodOpts().WeekStart(time.Sunday)
+	as.Equal(mustDate(2024, 8, 11), StartOf(d, PeriodWeek, sunday))
+	as.Equal(mustDate(2024, 8, 17), EndOf(d, PeriodWeek, sunday))
+	as.Equal(mustDate(2024, 8, 18), StartOf(mustDate(2024, 8, 18), PeriodWeek, sunday))
+
+	as.Equal(mustDate(2024, 2, 29), EndOf(mustDate(2024, 2, 3), PeriodMonth))
+	as.Equal(mustDate(2024, 3, 31), EndOf(mustDate(2024, 2, 3), PeriodQuarter))
+	as.Equal(NewDateRange(mustDate(2024, 4, 1), mustDate(2024, 6, 30)), PeriodOf(mustDate(2024, 5, 20), PeriodQuarter))
+
+	// 上月最后一天
+	as.Equal(mustDate(2024, 2, 29), EndOf(AddMonthsClamped(mustDate(2024, 3, 31), -1), PeriodMonth))
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	as := assert.New(t)
+	as.Equal(mustDate(2024, 2, 29), AddMonthsClamped(mustDate(2024, 1, 31), 1))
+	as.Equal(mustDate(2025, 2, 28), AddMonthsClamped(mustDate(2024, 2, 29), 12))
+	as.Equal(mustDate(2023, 11, 30), AddMonthsClamped(mustDate(2024, 1, 30), -2))
+	as.Equal(mustDate(2024, 1, 15), AddMonthsClamped(mustDate(2024, 1, 15), 0))
+}
+
+func TestISOWeekQuarter(t *testing.T) {
+	as := assert.New(t)
+	y, w := ISOWeek(mustDate(2021, 1, 3))
+	as.Equal([2]int{2020, 53}, [2]int{y, w})
+	y, w = ISOWeek(mustDate(2024, 12, 30))
+	as.Equal([2]int{2025, 1}, [2]int{y, w})
+	as.Equal(1, Quarter(mustDate(2024, 3, 31)))
+	as.Equal(4, Quarter(mustDate(2024, 10, 1)))
+}
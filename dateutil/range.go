@@ -0,0 +1,289 @@
+package dateutil
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// RangeBounds 日期区间的开闭类型。
+type RangeBounds uint8
+
+const (
+	// Closed [Start, End]，零值；请假、活动期等包含首尾两天的场景。
+	Closed RangeBounds = iota
+	// ClosedOpen [Start, End)；酒店入住/离店、租期等不含结束日的场景。
+	ClosedOpen
+	// OpenClosed (Start, End]
+	OpenClosed
+	// Open (Start, End)
+	Open
+)
+
+// DateRange 日期区间；Bounds 零值为 Closed（首尾都包含）。
+// 日期是离散的，运算时统一折算为闭区间 [First, Last]，结果沿用接收者的 Bounds 表示。
+type DateRange struct {
+	Start  types.LocalDate
+	End    types.LocalDate
+	Bounds RangeBounds
+}
+
+// NewDateRange 返回闭区间 [start, end]。
+func NewDateRange(start, end types.LocalDate) DateRange {
+	return DateRange{Start: start, End: end, Bounds: Closed}
+}
+
+// NewDateRangeHalfOpen 返回左闭右开区间 [start, end)。
+func NewDateRangeHalfOpen(start, end types.LocalDate) DateRange {
+	return DateRange{Start: start, End: end, Bounds: ClosedOpen}
+}
+
+// String 返回 "[2024-01-01, 2024-01-31)" 形式。
+func (r DateRange) String() string {
+	left, right := "[", "]"
+	if r.Bounds == OpenClosed || r.Bounds == Open {
+		left = "("
+	}
+	if r.Bounds == ClosedOpen || r.Bounds == Open {
+		right = ")"
+	}
+	return fmt.Sprintf("%s%s, %s%s", left, r.Start, r.End, right)
+}
+
+// closed 返回包含的首尾日期（epoch day），空区间时 first > last。
+func (r DateRange) closed() (first, last int64) {
+	first, last = epochDay(r.Start), epochDay(r.End)
+	if r.Bounds == OpenClosed || r.Bounds == Open {
+		first++
+	}
+	if r.Bounds == ClosedOpen || r.Bounds == Open {
+		last--
+	}
+	return first, last
+}
+
+// withClosed 按接收者的 Bounds 由闭区间首尾构造区间。
+func (r DateRange) withClosed(first, last int64) DateRange {
+	if r.Bounds == OpenClosed || r.Bounds == Open {
+		first--
+	}
+	if r.Bounds == ClosedOpen || r.Bounds == Open {
+		last++
+	}
+	return DateRange{Start: ofEpochDay(first), End: ofEpochDay(last), Bounds: r.Bounds}
+}
+
+// IsEmpty 判断区间是否不含任何日期。
+func (r DateRange) IsEmpty() bool {
+	first, last := r.closed()
+	return first > last
+}
+
+// First 返回区间包含的第一天；空区间返回 false。
+func (r DateRange) First() (types.LocalDate, bool) {
+	first, last := r.closed()
+	if first > last {
+		return types.LocalDate{}, false
+	}
+	return ofEpochDay(first), true
+}
+
+// Last 返回区间包含的最后一天；空区间返回 false。
+func (r DateRange) Last() (types.LocalDate, bool) {
+	first, last := r.closed()
+	if first > last {
+		return types.LocalDate{}, false
+	}
+	return ofEpochDay(last), true
+}
+
+// Days 返回区间包含的天数，空区间为 0。
+func (r DateRange) Days() int {
+	first, last := r.closed()
+	if first > last {
+		return 0
+	}
+	return int(last - first + 1)
+}
+
+// Contains 判断日期是否在区间内。
+func (r DateRange) Contains(d types.LocalDate) bool {
+	first, last := r.closed()
+	n := epochDay(d)
+	return n >= first && n <= last
+}
+
+// ContainsRange 判断 o 是否完全落在 r 内；空区间视为被任何区间包含。
+func (r DateRange) ContainsRange(o DateRange) bool {
+	of, ol := o.closed()
+	if of > ol {
+		return true
+	}
+	first, last := r.closed()
+	return of >= first && ol <= last
+}
+
+// Overlaps 判断两个区间是否有公共日期。
+func (r DateRange) Overlaps(o DateRange) bool {
+	_, ok := r.Intersect(o)
+	return ok
+}
+
+// Intersect 返回两个区间的交集；无交集时返回 false。
+func (r DateRange) Intersect(o DateRange) (DateRange, bool) {
+	first, last := r.closed()
+	of, ol := o.closed()
+	first, last = max(first, of), min(last, ol)
+	if first > last {
+		return DateRange{}, false
+	}
+	return r.withClosed(first, last), true
+}
+
+// Union 返回两个区间的并集；两区间既不重叠也不相邻时返回 false。
+// 任一为空区间时返回另一个。
+func (r DateRange) Union(o DateRange) (DateRange, bool) {
+	first, last := r.closed()
+	of, ol := o.closed()
+	switch {
+	case first > last && of > ol:
+		return r, true
+	case first > last:
+		return r.withClosed(of, ol), true
+	case of > ol:
+		return r, true
+	}
+	if of > last+1 || first > ol+1 {
+		return DateRange{}, false
+	}
+	return r.withClosed(min(first, of), max(last, ol)), true
+}
+
+// Subtract 返回 r 去掉 o 后剩余的部分，可能为 0~2 段。
+func (r DateRange) Subtract(o DateRange) []DateRange {
+	first, last := r.closed()
+	if first > last {
+		return nil
+	}
+	of, ol := o.closed()
+	if of > ol || ol < first || of > last {
+		return []DateRange{r.withClosed(first, last)}
+	}
+	var out []DateRange
+	if of > first {
+		out = append(out, r.withClosed(first, of-1))
+	}
+	if ol < last {
+		out = append(out, r.withClosed(ol+1, last))
+	}
+	return out
+}
+
+// MergeRanges 合并重叠或相邻的区间，按开始日期升序返回；空区间被忽略。
+// 结果采用第一个参数的 Bounds 表示。
+func MergeRanges(ranges ...DateRange) []DateRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+	proto := ranges[0]
+	type span struct{ first, last int64 }
+	spans := make([]span, 0, len(ranges))
+	for _, r := range ranges {
+		if first, last := r.closed(); first <= last {
+			spans = append(spans, span{first, last})
+		}
+	}
+	slices.SortFunc(spans, func(a, b span) int {
+		switch {
+		case a.first < b.first:
+			return -1
+		case a.first > b.first:
+			return 1
+		}
+		return 0
+	})
+
+	var out []DateRange
+	for i := 0; i < len(spans); {
+		cur := spans[i]
+		j := i + 1
+		for j < len(spans) && spans[j].first <= cur.last+1 {
+			cur.last = max(cur.last, spans[j].last)
+			j++
+		}
+		out = append(out, proto.withClosed(cur.first, cur.last))
+		i = j
+	}
+	return out
+}
+
+// EachDay 按天遍历区间内的日期。
+func (r DateRange) EachDay() iter.Seq[types.LocalDate] {
+	return r.EachNDays(1)
+}
+
+// EachWeek 从第一天起每隔 7 天遍历。
+func (r DateRange) EachWeek() iter.Seq[types.LocalDate] {
+	return r.EachNDays(7)
+}
+
+// EachNDays 从第一天起每隔 n 天遍历，n 必须大于 0。
+func (r DateRange) EachNDays(n int) iter.Seq[types.LocalDate] {
+	if n <= 0 {
+		panic("dateutil.EachNDays: n must be positive")
+	}
+	first, last := r.closed()
+	return func(yield func(types.LocalDate) bool) {
+		for d := first; d <= last; d += int64(n) {
+			if !yield(ofEpochDay(d)) {
+				return
+			}
+		}
+	}
+}
+
+// EachMonth 从第一天起按月遍历；日超出当月天数时取月末，如 1-31 → 2-29 → 3-31。
+func (r DateRange) EachMonth() iter.Seq[types.LocalDate] {
+	first, last := r.closed()
+	return func(yield func(types.LocalDate) bool) {
+		if first > last {
+			return
+		}
+		y, m, d := ymd(ofEpochDay(first))
+		for i := 0; ; i++ {
+			cur := ofYmdClamped(y, m+i, d)
+			if epochDay(cur) > last || !yield(cur) {
+				return
+			}
+		}
+	}
+}
+
+// SplitByWeek 按自然周切分区间，weekStart 为每周第一天（中国习惯为 time.Monday）。
+func (r DateRange) SplitByWeek(weekStart time.Weekday) []DateRange {
+	first, last := r.closed()
+	var out []DateRange
+	for cur := first; cur <= last; {
+		offset := (int(weekday(ofEpochDay(cur))) - int(weekStart) + 7) % 7
+		end := min(cur+int64(6-offset), last)
+		out = append(out, r.withClosed(cur, end))
+		cur = end + 1
+	}
+	return out
+}
+
+// SplitByMonth 按自然月切分区间。
+func (r DateRange) SplitByMonth() []DateRange {
+	first, last := r.closed()
+	var out []DateRange
+	for cur := first; cur <= last; {
+		y, m, d := ymd(ofEpochDay(cur))
+		end := min(cur+int64(daysIn(y, m)-d), last)
+		out = append(out, r.withClosed(cur, end))
+		cur = end + 1
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package dateutil
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateRange_bounds(t *testing.T) {
+	as := assert.New(t)
+	start, end := mustDate(2024, 1, 1), mustDate(2024, 1, 10)
+
+	as.Equal(10, NewDateRange(start, end).Days())
+	as.Equal(9, NewDateRangeHalfOpen(start, end).Days())
+	as.Equal(9, DateRange{Start: start, End: end, Bounds: OpenClosed}.Days())
+	as.Equal(8, DateRange{Start: start, End: end, Bounds: Open}.Days())
+	as.Equal(0, NewDateRangeHalfOpen(start, start).Days())
+	as.True(NewDateRangeHalfOpen(start, start).IsEmpty())
+	as.Equal(1, NewDateRange(start, start).Days())
+
+	as.Equal("[2024-01-01, 2024-01-10)", NewDateRangeHalfOpen(start, end).String())
+	as.Equal("(2024-01-01, 2024-01-10]", DateRange{Start: start, End: end, Bounds: OpenClosed}.String())
+
+	r := NewDateRangeHalfOpen(start, end)
+	as.True(r.Contains(start))
+	as.False(r.Contains(end))
+	first, ok := r.First()
+	as.True(ok)
+	as.Equal(start, first)
+	last, ok := r.Last()
+	as.True(ok)
+	as.Equal(mustDate(2024, 1, 9), last)
+
+	_, ok = NewDateRange(end, start).First()
+	as.False(ok)
+}
+
+func TestDateRange_Intersect(t *testing.T) {
+	as := assert.New(t)
+	a := NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 10))
+	b := NewDateRange(mustDate(2024, 1, 5), mustDate(2024, 1, 20))
+	c := NewDateRange(mustDate(2024, 1, 11), mustDate(2024, 1, 20))
+
+	got, ok := a.Intersect(b)
+	as.True(ok)
+	as.Equal(NewDateRange(mustDate(2024, 1, 5), mustDate(2024, 1, 10)), got)
+	as.True(a.Overlaps(b))
+	as.False(a.Overlaps(c))
+
+	// 半开区间：离店日与下一位入住日相同不算重叠
+	h1 := NewDateRangeHalfOpen(mustDate(2024, 1, 1), mustDate(2024, 1, 3))
+	h2 := NewDateRangeHalfOpen(mustDate(2024, 1, 3), mustDate(2024, 1, 5))
+	as.False(h1.Overlaps(h2))
+
+	as.True(a.ContainsRange(NewDateRange(mustDate(2024, 1, 2), mustDate(2024, 1, 3))))
+	as.False(a.ContainsRange(b))
+}
+
+func TestDateRange_Union(t *testing.T) {
+	as := assert.New(t)
+	a := NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 10))
+	adjacent := NewDateRange(mustDate(2024, 1, 11), mustDate(2024, 1, 20))
+	far := NewDateRange(mustDate(2024, 1, 12), mustDate(2024, 1, 20))
+
+	got, ok := a.Union(adjacent)
+	as.True(ok)
+	as.Equal(NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 20)), got)
+
+	_, ok = a.Union(far)
+	as.False(ok)
+
+	h := NewDateRangeHalfOpen(mustDate(2024, 1, 1), mustDate(2024, 1, 3))
+	got, ok = h.Union(NewDateRangeHalfOpen(mustDate(2024, 1, 3), mustDate(2024, 1, 5)))
+	as.True(ok)
+	as.Equal(NewDateRangeHalfOpen(mustDate(2024, 1, 1), mustDate(2024, 1, 5)), got)
+}
+
+func TestDateRange_Subtract(t *testing.T) {
+	as := assert.New(t)
+	a := NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 31))
+
+	got := a.Subtract(NewDateRange(mustDate(2024, 1, 10), mustDate(2024, 1, 12)))
+	as.Equal([]DateRange{
+		NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 9)),
+		NewDateRange(mustDate(2024, 1, 13), mustDate(2024, 1, 31)),
+	}, got)
+
+	as.Empty(a.Subtract(NewDateRange(mustDate(2023, 12, 1), mustDate(2024, 2, 1))))
+	as.Equal([]DateRange{a}, a.Subtract(NewDateRange(mustDate(2024, 3, 1), mustDate(2024, 3, 2))))
+
+	got = a.Subtract(NewDateRange(mustDate(2024, 1, 20), mustDate(2024, 2, 10)))
+	as.Equal([]DateRange{NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 19))}, got)
+}
+
+func TestMergeRanges(t *testing.T) {
+	as := assert.New(t)
+	got := MergeRanges(
+		NewDateRange(mustDate(2024, 3, 1), mustDate(2024, 3, 5)),
+		NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 10)),
+		NewDateRange(mustDate(2024, 1, 11), mustDate(2024, 1, 15)),
+		NewDateRange(mustDate(2024, 1, 5), mustDate(2024, 1, 7)),
+		NewDateRange(mustDate(2024, 5, 2), mustDate(2024, 5, 1)),
+	)
+	as.Equal([]DateRange{
+		NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 15)),
+		NewDateRange(mustDate(2024, 3, 1), mustDate(2024, 3, 5)),
+	}, got)
+	as.Nil(MergeRanges())
+}
+
+func TestDateRange_iterate(t *testing.T) {
+	as := assert.New(t)
+	r := NewDateRangeHalfOpen(mustDate(2024, 2, 27), mustDate(2024, 3, 2))
+	as.Equal([]types.LocalDate{
+		mustDate(2024, 2, 27), mustDate(2024, 2, 28), mustDate(2024, 2, 29), mustDate(2024, 3, 1),
+	}, slices.Collect(r.EachDay()))
+
+	weeks := slices.Collect(NewDateRange(mustDate(2024, 1, 1), mustDate(2024, 1, 20)).EachWeek())
+	as.Equal([]types.LocalDate{mustDate(2024, 1, 1), mustDate(2024, 1, 8), mustDate(2024, 1, 15)}, weeks)
+
+	months := slices.Collect(NewDateRange(mustDate(2024, 1, 31), mustDate(2024, 5, 1)).EachMonth())
+	as.Equal([]types.LocalDate{
+		mustDate(2024, 1, 31), mustDate(2024, 2, 29), mustDate(2024, 3, 31), mustDate(2024, 4, 30),
+	}, months)
+
+	count := 0
+	for range r.EachDay() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	as.Equal(2, count)
+	as.Panics(func() { r.EachNDays(0) })
+}
+
+func TestDateRange_Split(t *testing.T) {
+	as := assert.New(t)
+	// 2024-01-03 为星期三
+	r := NewDateRange(mustDate(2024, 1, 3), mustDate(2024, 1, 16))
+	as.Equal([]DateRange{
+		NewDateRange(mustDate(2024, 1, 3), mustDate(2024, 1, 7)),
+		NewDateRange(mustDate(2024, 1, 8), mustDate(2024, 1, 14)),
+		NewDateRange(mustDate(2024, 1, 15), mustDate(2024, 1, 16)),
+	}, r.SplitByWeek(time.Monday))
+
+	h := NewDateRangeHalfOpen(mustDate(2024, 1, 20), mustDate(2024, 3, 10))
+	as.Equal([]DateRange{
+		NewDateRangeHalfOpen(mustDate(2024, 1, 20), mustDate(2024, 2, 1)),
+		NewDateRangeHalfOpen(mustDate(2024, 2, 1), mustDate(2024, 3, 1)),
+		NewDateRangeHalfOpen(mustDate(2024, 3, 1), mustDate(2024, 3, 10)),
+	}, h.SplitByMonth())
+}
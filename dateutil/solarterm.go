@@ -0,0 +1,148 @@
+package dateutil
+
+import (
+	"math"
+	"sync"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// SolarTermNames 二十四节气名，按公历年内顺序（小寒起，冬至止）。
+var SolarTermNames = [24]string{
+	"小寒", "大寒", "立春", "雨水", "惊蛰", "春分",
+	"清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+	"小暑", "大暑", "立秋", "处暑", "白露", "秋分",
+	"寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+}
+
+// SolarTerm 一个节气及其交节时刻所在的日期（北京时间）。
+type SolarTerm struct {
+	Index int             // 在 SolarTermNames 中的下标
+	Name  string          // 节气名
+	Date  types.LocalDate // 交节日期
+}
+
+const (
+	solarTermMinYear = 1900
+	solarTermMaxYear = 2100
+	jdUnixEpoch      = 2440587.5 // 1970-01-01T00:00Z 的儒略日
+	jdJ2000          = 2451545.0
+)
+
+var solarTermCache sync.Map // year → [24]int64（交节日的 epochDay）
+
+// SolarTermsOf 返回公历某年的二十四节气（1900~2100），超出范围返回 nil。
+// 交节时刻按 VSOP87 截断级数计算太阳视黄经，精度约半分钟，日期按北京时间（UTC+8）。
+func SolarTermsOf(year int) []SolarTerm {
+	days, ok := solarTermDays(year)
+	if !ok {
+		return nil
+	}
+	out := make([]SolarTerm, 24)
+	for i, n := range days {
+		out[i] = SolarTerm{Index: i, Name: SolarTermNames[i], Date: ofEpochDay(n)}
+	}
+	return out
+}
+
+// SolarTermOn 返回某天交节的节气名；当天不是节气时返回 false。
+func SolarTermOn(d types.LocalDate) (string, bool) {
+	year, _, _ := ymd(d)
+	days, ok := solarTermDays(year)
+	if !ok {
+		return "", false
+	}
+	n := epochDay(d)
+	for i, day := range days {
+		if day == n {
+			return SolarTermNames[i], true
+		}
+	}
+	return "", false
+}
+
+func solarTermDays(year int) ([24]int64, bool) {
+	if year < solarTermMinYear || year > solarTermMaxYear {
+		return [24]int64{}, false
+	}
+	if v, ok := solarTermCache.Load(year); ok {
+		return v.([24]int64), true
+	}
+	var days [24]int64
+	for i := range days {
+		// 小寒为太阳视黄经 285°，此后每 15° 一个节气
+		target := math.Mod(285+15*float64(i), 360)
+		guess := float64(epochDay(types.LocalDateOfYmd(year, 1, 6))) + 15.22*float64(i) + jdUnixEpoch
+		jd := solveSunLongitude(target, guess)
+		beijing := jd + 8.0/24 - jdUnixEpoch
+		days[i] = int64(math.Floor(beijing))
+	}
+	solarTermCache.Store(year, days)
+	return days, true
+}
+
+// solveSunLongitude 求太阳视黄经等于 target（度）的时刻，返回 UT 儒略日。
+func solveSunLongitude(target, jd float64) float64 {
+	for range 20 {
+		diff := target - sunApparentLongitude(jd+deltaT(jd)/86400)
+		diff = math.Mod(diff+540, 360) - 180
+		step := diff / 360 * 365.2422
+		jd += step
+		if math.Abs(step) < 1e-7 {
+			break
+		}
+	}
+	return jd
+}
+
+// sunApparentLongitude 返回力学时儒略日 jde 的太阳视黄经（度）。
+func sunApparentLongitude(jde float64) float64 {
+	tau := (jde - jdJ2000) / 365250
+	series := func(terms [][3]float64) float64 {
+		s := 0.0
+		for _, t := range terms {
+			s += t[0] * math.Cos(t[1]+t[2]*tau)
+		}
+		return s
+	}
+	l := (series(earthL0) + tau*(series(earthL1)+tau*(series(earthL2)+
+		tau*(series(earthL3)+tau*(series(earthL4)+tau*series(earthL5)))))) / 1e8
+	sun := l*180/math.Pi + 180
+
+	// 转换到 FK5、章动与光行差
+	t := tau * 10
+	omega := (125.04452 - 1934.136261*t) * math.Pi / 180
+	ls := (280.4665 + 36000.7698*t) * math.Pi / 180
+	lm := (218.3165 + 481267.8813*t) * math.Pi / 180
+	nutation := -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+	sun += (-0.09033 + nutation - 20.4898) / 3600
+	return math.Mod(math.Mod(sun, 360)+360, 360)
+}
+
+// deltaT 返回 ΔT = TT - UT（秒），采用 Espenak & Meeus 分段多项式。
+func deltaT(jd float64) float64 {
+	y := 2000 + (jd-jdJ2000)/365.25
+	switch {
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	default:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+}
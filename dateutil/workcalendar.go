@@ -0,0 +1,265 @@
+package dateutil
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+//go:embed data/workcalendar/*.json
+var workCalendarFS embed.FS
+
+// WorkCalendarYear 一年的节假日安排，对应 data/workcalendar/<year>.json。
+type WorkCalendarYear struct {
+	Year     int                   `json:"year"`
+	Source   string                `json:"source,omitempty"`
+	Holidays []WorkCalendarHoliday `json:"holidays"`
+}
+
+// WorkCalendarHoliday 一个节日的放假区间 [Start, End] 与调休上班日。
+type WorkCalendarHoliday struct {
+	Name     string            `json:"name"`
+	Start    types.LocalDate   `json:"start"`
+	End      types.LocalDate   `json:"end"`
+	Workdays []types.LocalDate `json:"workdays"`
+}
+
+// WorkCalendar 工作日历：默认周六、周日休息，叠加法定节假日与调休上班日。
+// 查询方法可并发调用；修改方法（LoadYear、SetHoliday 等）不可与查询并发。
+type WorkCalendar struct {
+	weekend  [7]bool
+	holidays map[int64]string // 放假日 → 节日名
+	workdays map[int64]string // 调休上班日 → 节日名
+	manual   map[int64]bool   // SetHoliday/SetWorkday 手动设置的日期，LoadYear 不覆盖
+	years    map[int]bool
+}
+
+var (
+	defaultWorkCalendarOnce sync.Once
+	defaultWorkCalendar     *WorkCalendar
+	defaultWorkCalendarErr  error
+)
+
+// NewWorkCalendar 创建仅以周六、周日为休息日的空日历。
+func NewWorkCalendar() *WorkCalendar {
+	c := &WorkCalendar{
+		holidays: make(map[int64]string),
+		workdays: make(map[int64]string),
+		manual:   make(map[int64]bool),
+		years:    make(map[int]bool),
+	}
+	c.weekend[time.Saturday] = true
+	c.weekend[time.Sunday] = true
+	return c
+}
+
+// DefaultWorkCalendar 返回内建中国法定节假日日历的副本，可自由修改而不影响其他调用方。
+// 数据来自 data/workcalendar/*.json，由 gen_workcalendar.go 根据国务院通知生成。
+func DefaultWorkCalendar() *WorkCalendar {
+	defaultWorkCalendarOnce.Do(func() {
+		c := NewWorkCalendar()
+		entries, err := workCalendarFS.ReadDir("data/workcalendar")
+		if err != nil {
+			defaultWorkCalendarErr = err
+			return
+		}
+		for _, e := range entries {
+			raw, err := workCalendarFS.ReadFile(path.Join("data/workcalendar", e.Name()))
+			if err == nil {
+				err = c.LoadYearJSON(raw)
+			}
+			if err != nil {
+				defaultWorkCalendarErr = fmt.Errorf("加载内建节假日数据 %s 失败: %w", e.Name(), err)
+				return
+			}
+		}
+		defaultWorkCalendar = c
+	})
+	if defaultWorkCalendarErr != nil {
+		panic(defaultWorkCalendarErr)
+	}
+	return defaultWorkCalendar.Clone()
+}
+
+// Clone 返回日历的深拷贝。
+func (c *WorkCalendar) Clone() *WorkCalendar {
+	return &WorkCalendar{
+		weekend:  c.weekend,
+		holidays: maps.Clone(c.holidays),
+		workdays: maps.Clone(c.workdays),
+		manual:   maps.Clone(c.manual),
+		years:    maps.Clone(c.years),
+	}
+}
+
+// SetWeekend 设置每周固定休息日，如单休场景 SetWeekend(time.Sunday)。
+func (c *WorkCalendar) SetWeekend(days ...time.Weekday) *WorkCalendar {
+	c.weekend = [7]bool{}
+	for _, d := range days {
+		c.weekend[d] = true
+	}
+	return c
+}
+
+// LoadYearJSON 加载一年的节假日 JSON（格式同 data/workcalendar/<year>.json），覆盖规则同 LoadYear。
+func (c *WorkCalendar) LoadYearJSON(data []byte) error {
+	var y WorkCalendarYear
+	if err := json.Unmarshal(data, &y); err != nil {
+		return fmt.Errorf("节假日 JSON 解析失败: %w", err)
+	}
+	return c.LoadYear(y)
+}
+
+// LoadYear 加载一年的节假日安排，替换该年此前加载的节假日与调休数据；
+// SetHoliday、SetWorkday 手动设置的日期保留，不被覆盖。
+func (c *WorkCalendar) LoadYear(y WorkCalendarYear) error {
+	if y.Year <= 0 {
+		return fmt.Errorf("年份非法: %d", y.Year)
+	}
+	for _, h := range y.Holidays {
+		if h.End.Before(h.Start) {
+			return fmt.Errorf("%s 结束日期 %s 早于开始日期 %s", h.Name, h.End, h.Start)
+		}
+	}
+
+	inYear := func(n int64) bool {
+		year, _, _ := ymd(ofEpochDay(n))
+		return year == y.Year
+	}
+	loaded := func(n int64, _ string) bool { return inYear(n) && !c.manual[n] }
+	maps.DeleteFunc(c.holidays, loaded)
+	maps.DeleteFunc(c.workdays, loaded)
+
+	for _, h := range y.Holidays {
+		for n := epochDay(h.Start); n <= epochDay(h.End); n++ {
+			if !c.manual[n] {
+				c.holidays[n] = h.Name
+			}
+		}
+		for _, w := range h.Workdays {
+			if n := epochDay(w); !c.manual[n] {
+				c.workdays[n] = h.Name
+			}
+		}
+	}
+	c.years[y.Year] = true
+	return nil
+}
+
+// Years 返回已加载节假日数据的年份（升序）；超出这些年份时仅按周末判断。
+func (c *WorkCalendar) Years() []int {
+	out := make([]int, 0, len(c.years))
+	for y := range c.years {
+		out = append(out, y)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// SetHoliday 将某天设为休息日（如公司自定义假期），name 为说明。
+func (c *WorkCalendar) SetHoliday(d types.LocalDate, name string) *WorkCalendar {
+	n := epochDay(d)
+	delete(c.workdays, n)
+	c.holidays[n] = name
+	c.manual[n] = true
+	return c
+}
+
+// SetWorkday 将某天设为工作日（如调休上班、公司加班日），name 为说明。
+func (c *WorkCalendar) SetWorkday(d types.LocalDate, name string) *WorkCalendar {
+	n := epochDay(d)
+	delete(c.holidays, n)
+	c.workdays[n] = name
+	c.manual[n] = true
+	return c
+}
+
+// Holiday 返回某天所属的节日名；不是法定节假日（或自定义假期）时返回 false。
+func (c *WorkCalendar) Holiday(d types.LocalDate) (string, bool) {
+	name, ok := c.holidays[epochDay(d)]
+	return name, ok
+}
+
+// IsMakeupWorkday 判断某天是否为调休上班日（周末上班）。
+func (c *WorkCalendar) IsMakeupWorkday(d types.LocalDate) bool {
+	_, ok := c.workdays[epochDay(d)]
+	return ok
+}
+
+// IsWorkday 判断某天是否为工作日：调休上班日为工作日，节假日与周末为休息日。
+func (c *WorkCalendar) IsWorkday(d types.LocalDate) bool {
+	return c.isWorkday(epochDay(d))
+}
+
+func (c *WorkCalendar) isWorkday(n int64) bool {
+	if _, ok := c.workdays[n]; ok {
+		return true
+	}
+	if _, ok := c.holidays[n]; ok {
+		return false
+	}
+	return !c.weekend[weekday(ofEpochDay(n))]
+}
+
+// NextWorkday 返回 d 之后（不含 d）的第一个工作日。
+func (c *WorkCalendar) NextWorkday(d types.LocalDate) types.LocalDate {
+	return c.AddWorkdays(d, 1)
+}
+
+// PrevWorkday 返回 d 之前（不含 d）的最后一个工作日。
+func (c *WorkCalendar) PrevWorkday(d types.LocalDate) types.LocalDate {
+	return c.AddWorkdays(d, -1)
+}
+
+// AddWorkdays 返回 d 之后第 n 个工作日（不含 d 本身）；n 为负时向前数，n 为 0 返回 d。
+// 例如周五 AddWorkdays(1) 为下周一（遇节假日顺延）。
+func (c *WorkCalendar) AddWorkdays(d types.LocalDate, n int) types.LocalDate {
+	if n != 0 && c.weekend == [7]bool{true, true, true, true, true, true, true} {
+		panic("dateutil.AddWorkdays: every weekday is a rest day")
+	}
+	cur := epochDay(d)
+	step := int64(1)
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		cur += step
+		if c.isWorkday(cur) {
+			n--
+		}
+	}
+	return ofEpochDay(cur)
+}
+
+// WorkdaysBetween 统计 (start, end] 内的工作日数；end 早于 start 时统计 [end, start) 并返回负数。
+// 与 AddWorkdays 互逆：WorkdaysBetween(d, AddWorkdays(d, n)) == n。
+func (c *WorkCalendar) WorkdaysBetween(start, end types.LocalDate) int {
+	a, b := epochDay(start), epochDay(end)
+	if b < a {
+		return -c.countWorkdays(b, a-1)
+	}
+	return c.countWorkdays(a+1, b)
+}
+
+// WorkdaysIn 统计区间内的工作日数。
+func (c *WorkCalendar) WorkdaysIn(r DateRange) int {
+	first, last := r.closed()
+	return c.countWorkdays(first, last)
+}
+
+func (c *WorkCalendar) countWorkdays(first, last int64) int {
+	count := 0
+	for n := first; n <= last; n++ {
+		if c.isWorkday(n) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,90 @@
+package dateutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultWorkCalendar(t *testing.T) {
+	as := assert.New(t)
+	c := DefaultWorkCalendar()
+	as.Contains(c.Years(), 2025)
+
+	// 2025 春节 1-28 ~ 2-4，1-26（周日）、2-8（周六）调休上班
+	name, ok := c.Holiday(mustDate(2025, 1, 29))
+	as.True(ok)
+	as.Equal("春节", name)
+	as.False(c.IsWorkday(mustDate(2025, 1, 29)))
+	as.True(c.IsWorkday(mustDate(2025, 1, 26)))
+	as.True(c.IsMakeupWorkday(mustDate(2025, 2, 8)))
+	as.False(c.IsWorkday(mustDate(2025, 2, 9)), "普通周日")
+	as.True(c.IsWorkday(mustDate(2025, 2, 5)))
+
+	// 国庆中秋 2025-10-01 ~ 10-08
+	as.False(c.IsWorkday(mustDate(2025, 10, 8)))
+	as.True(c.IsWorkday(mustDate(2025, 10, 11)))
+}
+
+func TestWorkCalendar_AddWorkdays(t *testing.T) {
+	as := assert.New(t)
+	c := DefaultWorkCalendar()
+
+	// 2025-01-24（周五）之后：1-26 周日调休上班，1-27 周一，然后春节放假至 2-4
+	as.Equal(mustDate(2025, 1, 26), c.NextWorkday(mustDate(2025, 1, 24)))
+	as.Equal(mustDate(2025, 1, 27), c.AddWorkdays(mustDate(2025, 1, 24), 2))
+	as.Equal(mustDate(2025, 2, 5), c.AddWorkdays(mustDate(2025, 1, 24), 3))
+	as.Equal(mustDate(2025, 1, 27), c.PrevWorkday(mustDate(2025, 2, 5)))
+	as.Equal(mustDate(2025, 1, 24), c.AddWorkdays(mustDate(2025, 1, 24), 0))
+
+	for _, n := range []int{-15, -3, -1, 0, 1, 5, 30} {
+		d := mustDate(2025, 1, 20)
+		as.Equal(n, c.WorkdaysBetween(d, c.AddWorkdays(d, n)), n)
+	}
+
+	// 2025 年 10 月：18 天工作日（含 10-11 调休）
+	month := NewDateRange(mustDate(2025, 10, 1), mustDate(2025, 10, 31))
+	as.Equal(18, c.WorkdaysIn(month))
+}
+
+func TestWorkCalendar_override(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	c := DefaultWorkCalendar()
+	c.SetHoliday(mustDate(2025, 2, 5), "公司年假")
+	as.False(c.IsWorkday(mustDate(2025, 2, 5)))
+	as.True(DefaultWorkCalendar().IsWorkday(mustDate(2025, 2, 5)), "默认日历不受影响")
+
+	c.SetWorkday(mustDate(2025, 2, 9), "加班")
+	as.True(c.IsWorkday(mustDate(2025, 2, 9)))
+
+	req.NoError(c.LoadYearJSON([]byte(`{"year":2025,"holidays":[{"name":"自定义","start":"2025-03-03","end":"2025-03-04","workdays":["2025-03-08"]}]}`)))
+	as.True(c.IsWorkday(mustDate(2025, 1, 29)), "覆盖后 2025 年原数据被清除")
+	as.False(c.IsWorkday(mustDate(2025, 3, 3)))
+	as.True(c.IsWorkday(mustDate(2025, 3, 8)))
+	as.False(c.IsWorkday(mustDate(2025, 2, 5)), "手动设置的日期不被覆盖")
+	as.True(c.IsWorkday(mustDate(2025, 2, 9)))
+
+	c.SetWorkday(mustDate(2025, 3, 4), "值班")
+	req.NoError(c.LoadYearJSON([]byte(`{"year":2025,"holidays":[{"name":"自定义","start":"2025-03-03","end":"2025-03-05"}]}`)))
+	as.True(c.IsWorkday(mustDate(2025, 3, 4)))
+	as.False(c.IsWorkday(mustDate(2025, 3, 5)))
+	as.False(c.IsWorkday(mustDate(2025, 3, 8)), "再次加载替换上次加载的数据")
+
+	as.Error(c.LoadYearJSON([]byte(`{"year":2025,"holidays":[{"name":"x","start":"2025-03-05","end":"2025-03-04"}]}`)))
+	as.Error(c.LoadYearJSON([]byte(`not json`)))
+	as.Error(c.LoadYearJSON([]byte(`{"year":0}`)))
+}
+
+func TestWorkCalendar_weekend(t *testing.T) {
+	as := assert.New(t)
+	c := NewWorkCalendar().SetWeekend(time.Sunday)
+	as.True(c.IsWorkday(mustDate(2025, 3, 8)), "单休周六上班")
+	as.False(c.IsWorkday(mustDate(2025, 3, 9)))
+	as.Empty(c.Years())
+
+	all := NewWorkCalendar().SetWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	as.Panics(func() { all.AddWorkdays(mustDate(2025, 3, 8), 1) })
+}
@@ -0,0 +1,52 @@
+package dateutil
+
+import (
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+)
+
+// ymd 返回日期的年月日分量。
+func ymd(d types.LocalDate) (int, int, int) {
+	t := d.ToGoTime()
+	return t.Year(), int(t.Month()), t.Day()
+}
+
+// epochDay 返回距 1970-01-01 的天数，按 UTC 计算以规避夏令时。
+func epochDay(d types.LocalDate) int64 {
+	y, m, dd := ymd(d)
+	return time.Date(y, time.Month(m), dd, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// ofEpochDay 由距 1970-01-01 的天数构造日期。
+func ofEpochDay(n int64) types.LocalDate {
+	t := time.Unix(n*86400, 0).UTC()
+	return types.LocalDateOfYmd(t.Year(), int(t.Month()), t.Day())
+}
+
+// addDays 日期加减 n 天。
+func addDays(d types.LocalDate, n int) types.LocalDate {
+	return ofEpochDay(epochDay(d) + int64(n))
+}
+
+// daysIn 返回某年某月的天数。
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekday 返回日期是星期几。
+func weekday(d types.LocalDate) time.Weekday {
+	// 1970-01-01 为星期四
+	return time.Weekday((epochDay(d)%7 + 7 + 4) % 7)
+}
+
+// ofYmdClamped 构造日期，日超出当月天数时取月末；月份可超出 1~12，自动进位。
+func ofYmdClamped(year, month, day int) types.LocalDate {
+	year += (month - 1) / 12
+	month = (month-1)%12 + 1
+	if month <= 0 {
+		month += 12
+		year--
+	}
+	return types.LocalDateOfYmd(year, month, min(day, daysIn(year, month)))
+}
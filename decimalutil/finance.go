@@ -0,0 +1,362 @@
+package decimalutil
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoConvergence 迭代求解未能收敛，如现金流没有同时包含正负值或不存在实数解。
+var ErrNoConvergence = errors.New("迭代求解未收敛")
+
+// ErrInvalidFinanceArgs 财务函数参数非法，如 NPER 的对数参数不为正。
+var ErrInvalidFinanceArgs = errors.New("非法的财务函数参数")
+
+// finPrecision 财务函数内部除法、幂与对数运算的精度。
+const finPrecision = 20
+
+var one = decimal.NewFromInt(1)
+
+// PaymentTiming 付款时点，对应 Excel 财务函数的 type 参数。
+type PaymentTiming int
+
+const (
+	// PayAtEnd 期末付款（type = 0）
+	PayAtEnd PaymentTiming = iota
+	// PayAtBeginning 期初付款（type = 1）
+	PayAtBeginning
+)
+
+func (w PaymentTiming) factor(rate decimal.Decimal) decimal.Decimal {
+	if w == PayAtBeginning {
+		return one.Add(rate)
+	}
+	return one
+}
+
+// CashFlow 带日期的现金流，流出为负、流入为正。
+type CashFlow struct {
+	Date   types.LocalDate
+	Amount decimal.Decimal
+}
+
+type solveOpts struct {
+	guess     decimal.Decimal
+	maxIter   int
+	tolerance decimal.Decimal
+}
+
+// SolveOpts 创建 IRR/XIRR 求解选项，默认初值 0.1、最多迭代 100 次、精度 1e-12，与 Excel 一致。
+func SolveOpts() *solveOpts {
+	return &solveOpts{guess: decimal.RequireFromString("0.1"), maxIter: 100, tolerance: decimal.New(1, -12)}
+}
+
+// Guess 设置牛顿迭代初值。
+func (o *solveOpts) Guess(g decimal.Decimal) *solveOpts {
+	o.guess = g
+	return o
+}
+
+// MaxIterations 设置牛顿迭代的最大次数，超过后改用二分法。
+func (o *solveOpts) MaxIterations(n int) *solveOpts {
+	o.maxIter = n
+	return o
+}
+
+// Tolerance 设置收敛精度：相邻两次迭代结果之差小于该值时停止。
+func (o *solveOpts) Tolerance(t decimal.Decimal) *solveOpts {
+	o.tolerance = t
+	return o
+}
+
+func resolveSolveOpts(opts ...*solveOpts) *solveOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return SolveOpts()
+	}
+	return opts[0]
+}
+
+// NPV 净现值，与 Excel NPV 一致：第 i 个现金流（从 1 开始）折现 i 期，即第一个现金流也折现一期。
+// 期初投资不应折现时，应在结果上另行加上第 0 期现金流。rate 不大于 -1 时返回 ErrInvalidFinanceArgs。
+func NPV(rate decimal.Decimal, values ...decimal.Decimal) (decimal.Decimal, error) {
+	if !one.Add(rate).IsPositive() {
+		return decimal.Zero, fmt.Errorf("%w: 折现率 %s 不大于 -1", ErrInvalidFinanceArgs, rate)
+	}
+	v := one.DivRound(one.Add(rate), finPrecision)
+	npv, disc := decimal.Zero, one
+	for _, cf := range values {
+		disc = disc.Mul(v).Round(finPrecision)
+		npv = npv.Add(cf.Mul(disc))
+	}
+	return npv.Round(finPrecision), nil
+}
+
+// IRR 内部收益率，与 Excel IRR 一致：求使 values[0] + Σ values[i] / (1+r)^i = 0 的 r。
+// 先以牛顿法迭代，不收敛时改用二分法在 (-1, +∞) 内搜索；仍无解时返回 ErrNoConvergence。
+func IRR(values []decimal.Decimal, opts ...*solveOpts) (decimal.Decimal, error) {
+	times := make([]decimal.Decimal, len(values))
+	for i := range values {
+		times[i] = decimal.NewFromInt(int64(i))
+	}
+	return solveRate(values, times, resolveSolveOpts(opts...))
+}
+
+// XNPV 按实际日期折现的净现值，与 Excel XNPV 一致：第 i 个现金流折现 (日期 - 第一个日期) / 365 年。
+// rate 不大于 -1 时返回 ErrInvalidFinanceArgs。
+func XNPV(rate decimal.Decimal, flows []CashFlow) (decimal.Decimal, error) {
+	values, times := xflows(flows)
+	npv, _, ok := npvAt(rate, values, times)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: 折现率 %s 不大于 -1", ErrInvalidFinanceArgs, rate)
+	}
+	return npv.Round(finPrecision), nil
+}
+
+// XIRR 按实际日期计算的年化内部收益率，与 Excel XIRR 一致。
+func XIRR(flows []CashFlow, opts ...*solveOpts) (decimal.Decimal, error) {
+	values, times := xflows(flows)
+	return solveRate(values, times, resolveSolveOpts(opts...))
+}
+
+// PMT 每期付款额，与 Excel PMT(rate, nper, pv, fv, type) 一致，付款为负值。
+// nper 为 0 或 (1+rate)^nper 无实数值时返回 ErrInvalidFinanceArgs。
+func PMT(rate, nper, pv, fv decimal.Decimal, when PaymentTiming) (decimal.Decimal, error) {
+	if nper.IsZero() {
+		return decimal.Zero, fmt.Errorf("%w: 期数为 0", ErrInvalidFinanceArgs)
+	}
+	if rate.IsZero() {
+		return pv.Add(fv).Neg().DivRound(nper, finPrecision), nil
+	}
+	f, err := compound(rate, nper)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	// pmt = -(fv + pv × f) × r / ((1 + r × type) × (f - 1))
+	den := when.factor(rate).Mul(f.Sub(one))
+	if den.IsZero() {
+		return decimal.Zero, fmt.Errorf("%w: 利率 %s 与期数 %s 使分母为 0", ErrInvalidFinanceArgs, rate, nper)
+	}
+	return fv.Add(pv.Mul(f)).Mul(rate).Neg().DivRound(den, finPrecision), nil
+}
+
+// PV 现值，与 Excel PV(rate, nper, pmt, fv, type) 一致。(1+rate)^nper 为 0 或无实数值时返回 ErrInvalidFinanceArgs。
+func PV(rate, nper, pmt, fv decimal.Decimal, when PaymentTiming) (decimal.Decimal, error) {
+	if rate.IsZero() {
+		return pmt.Mul(nper).Add(fv).Neg(), nil
+	}
+	f, err := compound(rate, nper)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if f.IsZero() {
+		return decimal.Zero, fmt.Errorf("%w: 利率 %s 使 (1+rate)^nper 为 0", ErrInvalidFinanceArgs, rate)
+	}
+	// pv = -(fv + pmt × (1 + r × type) × (f - 1) / r) / f
+	annuity := pmt.Mul(when.factor(rate)).Mul(f.Sub(one)).DivRound(rate, finPrecision)
+	return fv.Add(annuity).Neg().DivRound(f, finPrecision), nil
+}
+
+// FV 终值，与 Excel FV(rate, nper, pmt, pv, type) 一致。(1+rate)^nper 无实数值时返回 ErrInvalidFinanceArgs。
+func FV(rate, nper, pmt, pv decimal.Decimal, when PaymentTiming) (decimal.Decimal, error) {
+	if rate.IsZero() {
+		return pv.Add(pmt.Mul(nper)).Neg(), nil
+	}
+	f, err := compound(rate, nper)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	// fv = -(pv × f + pmt × (1 + r × type) × (f - 1) / r)
+	annuity := pmt.Mul(when.factor(rate)).Mul(f.Sub(one)).DivRound(rate, finPrecision)
+	return pv.Mul(f).Add(annuity).Neg().Round(finPrecision), nil
+}
+
+// NPER 期数，与 Excel NPER(rate, pmt, pv, fv, type) 一致。无解时（如每期付款不足以覆盖利息）返回 ErrInvalidFinanceArgs。
+func NPER(rate, pmt, pv, fv decimal.Decimal, when PaymentTiming) (decimal.Decimal, error) {
+	if rate.IsZero() {
+		if pmt.IsZero() {
+			return decimal.Zero, fmt.Errorf("%w: 利率与每期付款均为 0", ErrInvalidFinanceArgs)
+		}
+		return pv.Add(fv).Neg().DivRound(pmt, finPrecision), nil
+	}
+	// nper = ln((pmt × (1 + r × type) - fv × r) / (pmt × (1 + r × type) + pv × r)) / ln(1 + r)
+	p := pmt.Mul(when.factor(rate))
+	den := p.Add(pv.Mul(rate))
+	if den.IsZero() {
+		return decimal.Zero, fmt.Errorf("%w: 每期付款恰好等于利息，期数无穷大", ErrInvalidFinanceArgs)
+	}
+	ratio := p.Sub(fv.Mul(rate)).DivRound(den, finPrecision)
+	if !ratio.IsPositive() || one.Add(rate).Sign() <= 0 {
+		return decimal.Zero, fmt.Errorf("%w: 无实数解", ErrInvalidFinanceArgs)
+	}
+	num, err := ratio.Ln(finPrecision)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: %v", ErrInvalidFinanceArgs, err)
+	}
+	base, err := one.Add(rate).Ln(finPrecision)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: %v", ErrInvalidFinanceArgs, err)
+	}
+	return num.DivRound(base, finPrecision), nil
+}
+
+// compound 返回 (1 + rate)^nper，nper 可为小数；1+rate 为负且 nper 为小数、或 1+rate 为 0 且 nper 为负时无实数值。
+func compound(rate, nper decimal.Decimal) (decimal.Decimal, error) {
+	f, err := power(one.Add(rate), nper)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: (1 + %s)^%s 无实数值", ErrInvalidFinanceArgs, rate, nper)
+	}
+	return f, nil
+}
+
+// power 返回 base^exp，结果保留 finPrecision 位小数。
+func power(base, exp decimal.Decimal) (decimal.Decimal, error) {
+	if base.IsZero() && exp.IsNegative() {
+		return decimal.Zero, fmt.Errorf("0 的负数次幂无定义")
+	}
+	if exp.IsInteger() && exp.Abs().LessThanOrEqual(decimal.NewFromInt(1<<31-1)) {
+		f, err := base.PowInt32(int32(exp.IntPart()))
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return f.Round(finPrecision), nil
+	}
+	return base.PowWithPrecision(exp, finPrecision)
+}
+
+// xflows 将带日期的现金流转换为金额与距第一个日期的年数（按 365 天计）。
+func xflows(flows []CashFlow) ([]decimal.Decimal, []decimal.Decimal) {
+	values := make([]decimal.Decimal, len(flows))
+	times := make([]decimal.Decimal, len(flows))
+	if len(flows) == 0 {
+		return values, times
+	}
+	d0 := epochDay(flows[0].Date)
+	year := decimal.NewFromInt(365)
+	for i, f := range flows {
+		values[i] = f.Amount
+		times[i] = decimal.NewFromInt(epochDay(f.Date)-d0).DivRound(year, finPrecision)
+	}
+	return values, times
+}
+
+func epochDay(d types.LocalDate) int64 {
+	t := d.ToGoTime()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// npvAt 返回 Σ values[i] × (1+r)^-times[i] 及其对 r 的导数；r 不大于 -1 时 ok 为 false。
+// 折现因子以乘法计算，r 接近 -1 或很大时只会得到很大或接近 0 的值，不会除以 0。
+func npvAt(rate decimal.Decimal, values, times []decimal.Decimal) (npv, d decimal.Decimal, ok bool) {
+	base := one.Add(rate)
+	if !base.IsPositive() {
+		return decimal.Zero, decimal.Zero, false
+	}
+	v := one.DivRound(base, finPrecision)
+	var disc decimal.Decimal
+	for i, cf := range values {
+		if i > 0 && times[i].Sub(times[i-1]).Equal(one) {
+			// 等间隔的期数逐期累乘，避免每期重新求幂
+			disc = disc.Mul(v).Round(finPrecision)
+		} else {
+			var err error
+			if disc, err = power(v, times[i]); err != nil {
+				return decimal.Zero, decimal.Zero, false
+			}
+		}
+		npv = npv.Add(cf.Mul(disc))
+		// d/dr cf × (1+r)^-t = -t × cf × (1+r)^(-t-1)
+		d = d.Sub(times[i].Mul(cf).Mul(disc).Mul(v))
+	}
+	return npv, d, true
+}
+
+// solveRate 求 npvAt(r) = 0 的根：牛顿法失败时退回二分法。
+func solveRate(values, times []decimal.Decimal, o *solveOpts) (decimal.Decimal, error) {
+	var pos, neg bool
+	for _, v := range values {
+		pos = pos || v.IsPositive()
+		neg = neg || v.IsNegative()
+	}
+	if !pos || !neg {
+		return decimal.Zero, fmt.Errorf("%w: 现金流需同时包含正值与负值", ErrNoConvergence)
+	}
+
+	if r, ok := newtonRate(values, times, o); ok {
+		return r.Round(finPrecision - 4), nil
+	}
+	if r, ok := bisectRate(values, times, o); ok {
+		return r.Round(finPrecision - 4), nil
+	}
+	return decimal.Zero, fmt.Errorf("%w: 牛顿法与二分法均未找到解", ErrNoConvergence)
+}
+
+func newtonRate(values, times []decimal.Decimal, o *solveOpts) (decimal.Decimal, bool) {
+	minRate := one.Neg()
+	r := o.guess
+	for range o.maxIter {
+		if r.LessThanOrEqual(minRate) {
+			return r, false
+		}
+		npv, d, ok := npvAt(r, values, times)
+		if !ok || d.IsZero() {
+			return r, false
+		}
+		next := r.Sub(npv.DivRound(d, finPrecision))
+		if next.Sub(r).Abs().LessThan(o.tolerance) {
+			return next, next.GreaterThan(minRate)
+		}
+		r = next
+	}
+	return r, false
+}
+
+// bisectMaxIter 二分法的最大迭代次数，足以将宽度 2^20 的区间缩小到 1e-12 以下。
+const bisectMaxIter = 200
+
+func bisectRate(values, times []decimal.Decimal, o *solveOpts) (decimal.Decimal, bool) {
+	two, ten := decimal.NewFromInt(2), decimal.NewFromInt(10)
+	lo, hi := decimal.RequireFromString("-0.9"), one
+	fLo, _, okLo := npvAt(lo, values, times)
+	fHi, _, okHi := npvAt(hi, values, times)
+	// 区间两端同号时交替向 -1 与正无穷扩展，直到异号
+	for i := 0; fLo.Sign()*fHi.Sign() > 0; i++ {
+		if i >= 40 || !okLo || !okHi {
+			return decimal.Zero, false
+		}
+		if i%2 == 0 {
+			hi = hi.Mul(two)
+			fHi, _, okHi = npvAt(hi, values, times)
+		} else {
+			lo = lo.Add(one).Div(ten).Sub(one)
+			fLo, _, okLo = npvAt(lo, values, times)
+		}
+	}
+	if !okLo || !okHi {
+		return decimal.Zero, false
+	}
+	if fLo.IsZero() {
+		return lo, true
+	}
+	if fHi.IsZero() {
+		return hi, true
+	}
+	for range bisectMaxIter {
+		mid := lo.Add(hi).Div(two)
+		fMid, _, ok := npvAt(mid, values, times)
+		if !ok {
+			return decimal.Zero, false
+		}
+		if fMid.IsZero() || hi.Sub(lo).LessThan(o.tolerance) {
+			return mid, true
+		}
+		if fMid.Sign() == fLo.Sign() {
+			lo, fLo = mid, fMid
+		} else {
+			hi = mid
+		}
+	}
+	return decimal.Zero, false
+}
@@ -0,0 +1,177 @@
+package decimalutil
+
+import (
+	"testing"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decs(ss ...string) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(ss))
+	for i, s := range ss {
+		out[i] = decimal.RequireFromString(s)
+	}
+	return out
+}
+
+func dec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// 期望值均取自 Excel 对应函数的输出。
+func TestNPV(t *testing.T) {
+	as := assert.New(t)
+	npv := func(rate string, values ...string) string {
+		d, err := NPV(dec(rate), decs(values...)...)
+		require.NoError(t, err)
+		return d.StringFixed(2)
+	}
+	as.Equal("1188.44", npv("0.1", "-10000", "3000", "4200", "6800"))
+	as.Equal("41922.06", npv("0.08", "8000", "9200", "10000", "12000", "14500"))
+	as.Equal("0.00", npv("0.1"))
+	_, err := NPV(dec("-1"), decs("100")...)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+}
+
+func TestIRR(t *testing.T) {
+	as := assert.New(t)
+	r, err := IRR(decs("-70000", "12000", "15000", "18000", "21000", "26000"))
+	require.NoError(t, err)
+	as.Equal("0.0866309480", r.StringFixed(10))
+
+	r, err = IRR(decs("-70000", "12000", "15000", "18000", "21000"))
+	require.NoError(t, err)
+	as.Equal("-0.0212448483", r.StringFixed(10))
+
+	r, err = IRR(decs("-70000", "12000", "15000"), SolveOpts().Guess(dec("-0.1")))
+	require.NoError(t, err)
+	as.Equal("-0.4435069413", r.StringFixed(10))
+
+	// 初值离解很远时牛顿法发散，由二分法兜底
+	r, err = IRR(decs("-100", "300"), SolveOpts().Guess(dec("-0.99")).MaxIterations(5))
+	require.NoError(t, err)
+	as.Equal("2.0000000000", r.StringFixed(10))
+
+	// 30 年房贷：默认初值离解较远，折现因子不能因 r 接近 -1 而除以 0
+	mortgage := append(decs("-100000"), make([]decimal.Decimal, 360)...)
+	for i := 1; i <= 360; i++ {
+		mortgage[i] = dec("600")
+	}
+	r, err = IRR(mortgage)
+	require.NoError(t, err)
+	as.Equal("0.0050058250", r.StringFixed(10))
+
+	// 牛顿法迭代次数不足时由二分法求解
+	flows := append(decs("-1000"), make([]decimal.Decimal, 10)...)
+	for i := 1; i <= 10; i++ {
+		flows[i] = dec("150")
+	}
+	r, err = IRR(flows, SolveOpts().MaxIterations(1))
+	require.NoError(t, err)
+	as.Equal("0.0814416565", r.StringFixed(10))
+
+	_, err = IRR(decs("100", "200"))
+	as.ErrorIs(err, ErrNoConvergence)
+	_, err = IRR(nil)
+	as.ErrorIs(err, ErrNoConvergence)
+}
+
+func TestXIRR(t *testing.T) {
+	as := assert.New(t)
+	date := func(s string) types.LocalDate {
+		d, err := types.LocalDateParse(s)
+		require.NoError(t, err)
+		return d
+	}
+	flows := []CashFlow{
+		{Date: date("2008-01-01"), Amount: dec("-10000")},
+		{Date: date("2008-03-01"), Amount: dec("2750")},
+		{Date: date("2008-10-30"), Amount: dec("4250")},
+		{Date: date("2009-02-15"), Amount: dec("3250")},
+		{Date: date("2009-04-01"), Amount: dec("2750")},
+	}
+	r, err := XIRR(flows)
+	require.NoError(t, err)
+	as.Equal("0.37336253", r.StringFixed(8))
+	npv, err := XNPV(dec("0.09"), flows)
+	require.NoError(t, err)
+	as.Equal("2086.647602", npv.StringFixed(6))
+	_, err = XNPV(dec("-1.5"), flows)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+
+	_, err = XIRR(flows[:1])
+	as.ErrorIs(err, ErrNoConvergence)
+}
+
+func TestPMTPVFV(t *testing.T) {
+	as := assert.New(t)
+	fixed := func(places int32) func(decimal.Decimal, error) string {
+		return func(d decimal.Decimal, err error) string {
+			require.NoError(t, err)
+			return d.StringFixed(places)
+		}
+	}
+	cents := fixed(2)
+	monthly := dec("0.08").Div(dec("12"))
+	as.Equal("-1037.03", cents(PMT(monthly, dec("10"), dec("10000"), decimal.Zero, PayAtEnd)))
+	as.Equal("-1030.16", cents(PMT(monthly, dec("10"), dec("10000"), decimal.Zero, PayAtBeginning)))
+	as.Equal("-129.08", cents(PMT(dec("0.005"), dec("216"), decimal.Zero, dec("50000"), PayAtEnd)))
+	as.Equal("-100.00", cents(PMT(decimal.Zero, dec("10"), dec("1000"), decimal.Zero, PayAtEnd)))
+
+	as.Equal("-59777.15", cents(PV(monthly, dec("240"), dec("500"), decimal.Zero, PayAtEnd)))
+	as.Equal("-5000.00", cents(PV(decimal.Zero, dec("10"), dec("500"), decimal.Zero, PayAtEnd)))
+
+	as.Equal("2581.40", cents(FV(dec("0.005"), dec("10"), dec("-200"), dec("-500"), PayAtBeginning)))
+	as.Equal("12682.50", cents(FV(dec("0.01"), dec("12"), dec("-1000"), decimal.Zero, PayAtEnd)))
+	// 小数期数
+	as.Equal("-1.1052545703", fixed(10)(FV(dec("0.1"), dec("1.05"), decimal.Zero, dec("1"), PayAtEnd)))
+
+	// 非法参数返回错误而不是 panic
+	_, err := PMT(monthly, decimal.Zero, dec("1000"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = PMT(dec("-2"), dec("1.5"), dec("1000"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = PV(dec("-2"), dec("1.5"), dec("100"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = PV(dec("-1"), dec("3"), dec("100"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = FV(dec("-2"), dec("1.5"), dec("100"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	// 1+rate 为 0 且期数为负
+	_, err = PV(dec("-1"), dec("-2"), dec("1"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = FV(dec("-1"), dec("-3"), dec("1"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = FV(dec("-1"), dec("-1.5"), dec("1"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = PMT(dec("-1"), dec("-2"), dec("1"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+}
+
+func TestNPER(t *testing.T) {
+	as := assert.New(t)
+	n, err := NPER(dec("0.01"), dec("-100"), dec("-1000"), dec("10000"), PayAtBeginning)
+	require.NoError(t, err)
+	as.Equal("59.6738657", n.StringFixed(7))
+
+	n, err = NPER(dec("0.01"), dec("-100"), dec("-1000"), dec("10000"), PayAtEnd)
+	require.NoError(t, err)
+	as.Equal("60.0821229", n.StringFixed(7))
+
+	n, err = NPER(dec("0.01"), dec("-100"), dec("-1000"), decimal.Zero, PayAtEnd)
+	require.NoError(t, err)
+	as.Equal("-9.5785940", n.StringFixed(7))
+
+	n, err = NPER(decimal.Zero, dec("-100"), dec("1000"), decimal.Zero, PayAtEnd)
+	require.NoError(t, err)
+	as.Equal("10", n.String())
+
+	// 每期付款不足以支付利息
+	_, err = NPER(dec("0.01"), dec("-5"), dec("1000"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+	_, err = NPER(decimal.Zero, decimal.Zero, dec("1000"), decimal.Zero, PayAtEnd)
+	as.ErrorIs(err, ErrInvalidFinanceArgs)
+}
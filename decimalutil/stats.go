@@ -0,0 +1,290 @@
+package decimalutil
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+// statsPrecision 方差、标准差等统计量中除法与开方的精度。
+const statsPrecision = 16
+
+// PercentileMethod 百分位数落在两个数据点之间时的取值方式，与 numpy.percentile 的 method 参数一致。
+type PercentileMethod int
+
+const (
+	// PercentileLinear 线性插值，与 Excel PERCENTILE.INC 一致
+	PercentileLinear PercentileMethod = iota
+	// PercentileLower 取较小的数据点
+	PercentileLower
+	// PercentileHigher 取较大的数据点
+	PercentileHigher
+	// PercentileNearest 取较近的数据点，距离相同时取下标为偶数者
+	PercentileNearest
+	// PercentileMidpoint 取两个数据点的平均值
+	PercentileMidpoint
+)
+
+// sorted 返回升序排列的副本，不修改原切片。
+func sorted(values []decimal.Decimal) []decimal.Decimal {
+	s := slices.Clone(values)
+	slices.SortFunc(s, decimal.Decimal.Cmp)
+	return s
+}
+
+// Median 中位数，个数为偶数时取中间两个数的平均值；为空时返回 0。
+func Median(values ...decimal.Decimal) decimal.Decimal {
+	return Percentile(values, decimal.New(5, -1), PercentileLinear)
+}
+
+// Percentile 第 p 百分位数，p 取 0~1（如 0.9 表示 P90），按 method 在相邻数据点间取值；为空时返回 0。
+// p 超出范围时 panic。
+func Percentile(values []decimal.Decimal, p decimal.Decimal, method PercentileMethod) decimal.Decimal {
+	if p.IsNegative() || p.GreaterThan(one) {
+		panic(fmt.Sprintf("percentile %s out of range [0, 1]", p))
+	}
+	if len(values) == 0 {
+		return decimal.Zero
+	}
+	s := sorted(values)
+	h := p.Mul(decimal.NewFromInt(int64(len(s) - 1)))
+	lo := int(h.IntPart())
+	frac := h.Sub(decimal.NewFromInt(int64(lo)))
+	if frac.IsZero() {
+		return s[lo]
+	}
+	a, b := s[lo], s[lo+1]
+	switch method {
+	case PercentileLower:
+		return a
+	case PercentileHigher:
+		return b
+	case PercentileNearest:
+		switch frac.Cmp(decimal.New(5, -1)) {
+		case -1:
+			return a
+		case 1:
+			return b
+		}
+		if lo%2 == 0 {
+			return a
+		}
+		return b
+	case PercentileMidpoint:
+		return a.Add(b).Div(decimal.NewFromInt(2))
+	}
+	return a.Add(b.Sub(a).Mul(frac))
+}
+
+// Mode 众数，出现次数最多的值按升序返回，数值相等即视为相同（如 1.0 与 1）；为空时返回 nil。
+func Mode(values ...decimal.Decimal) []decimal.Decimal {
+	if len(values) == 0 {
+		return nil
+	}
+	s := sorted(values)
+	var modes []decimal.Decimal
+	best := 0
+	for i := 0; i < len(s); {
+		j := i + 1
+		for j < len(s) && s[j].Equal(s[i]) {
+			j++
+		}
+		switch n := j - i; {
+		case n > best:
+			best, modes = n, []decimal.Decimal{s[i]}
+		case n == best:
+			modes = append(modes, s[i])
+		}
+		i = j
+	}
+	return modes
+}
+
+// WeightedAverage 加权平均数 Σ(value × weight) / Σweight。
+// values 与 weights 长度不同或权重之和为 0 时 panic；为空时返回 0。
+func WeightedAverage(values, weights []decimal.Decimal) decimal.Decimal {
+	if len(values) != len(weights) {
+		panic("values and weights must have the same length")
+	}
+	if len(values) == 0 {
+		return decimal.Zero
+	}
+	sum, wsum := decimal.Zero, decimal.Zero
+	for i, v := range values {
+		sum = sum.Add(v.Mul(weights[i]))
+		wsum = wsum.Add(weights[i])
+	}
+	if wsum.IsZero() {
+		panic("sum of weights cannot be zero")
+	}
+	return sum.DivRound(wsum, statsPrecision)
+}
+
+// VariancePopulation 总体方差，与 Excel VAR.P 一致；为空时返回 0。
+func VariancePopulation(values ...decimal.Decimal) decimal.Decimal {
+	var acc Accumulator
+	acc.Add(values...)
+	return acc.VariancePopulation()
+}
+
+// VarianceSample 样本方差（除以 n-1），与 Excel VAR.S 一致；少于 2 个值时返回 0。
+func VarianceSample(values ...decimal.Decimal) decimal.Decimal {
+	var acc Accumulator
+	acc.Add(values...)
+	return acc.VarianceSample()
+}
+
+// StdDevPopulation 总体标准差，与 Excel STDEV.P 一致。
+func StdDevPopulation(values ...decimal.Decimal) decimal.Decimal {
+	return sqrt(VariancePopulation(values...))
+}
+
+// StdDevSample 样本标准差，与 Excel STDEV.S 一致。
+func StdDevSample(values ...decimal.Decimal) decimal.Decimal {
+	return sqrt(VarianceSample(values...))
+}
+
+func sqrt(d decimal.Decimal) decimal.Decimal {
+	if !d.IsPositive() {
+		return decimal.Zero
+	}
+	r, err := d.PowWithPrecision(decimal.New(5, -1), statsPrecision)
+	if err != nil {
+		panic(err)
+	}
+	return r.Round(statsPrecision)
+}
+
+// Accumulator 流式统计累加器，逐个或分批加入数据后随时读取个数、合计、均值、极值与方差，
+// 不保存数据本身，全程使用 decimal 精确累加合计与平方和。零值可直接使用，非并发安全。
+type Accumulator struct {
+	count    int64
+	sum      decimal.Decimal
+	sumSq    decimal.Decimal
+	min, max decimal.Decimal
+}
+
+// Add 加入数据。
+func (a *Accumulator) Add(values ...decimal.Decimal) {
+	for _, v := range values {
+		if a.count == 0 || v.LessThan(a.min) {
+			a.min = v
+		}
+		if a.count == 0 || v.GreaterThan(a.max) {
+			a.max = v
+		}
+		a.count++
+		a.sum = a.sum.Add(v)
+		a.sumSq = a.sumSq.Add(v.Mul(v))
+	}
+}
+
+// Merge 合并另一个累加器的数据，便于分片并行统计后汇总。
+func (a *Accumulator) Merge(b Accumulator) {
+	if b.count == 0 {
+		return
+	}
+	if a.count == 0 || b.min.LessThan(a.min) {
+		a.min = b.min
+	}
+	if a.count == 0 || b.max.GreaterThan(a.max) {
+		a.max = b.max
+	}
+	a.count += b.count
+	a.sum = a.sum.Add(b.sum)
+	a.sumSq = a.sumSq.Add(b.sumSq)
+}
+
+// Count 数据个数。
+func (a Accumulator) Count() int64 {
+	return a.count
+}
+
+// Sum 合计。
+func (a Accumulator) Sum() decimal.Decimal {
+	return a.sum
+}
+
+// Mean 平均值，没有数据时返回 0。
+func (a Accumulator) Mean() decimal.Decimal {
+	if a.count == 0 {
+		return decimal.Zero
+	}
+	return a.sum.DivRound(decimal.NewFromInt(a.count), statsPrecision)
+}
+
+// Min 最小值，没有数据时返回 0。
+func (a Accumulator) Min() decimal.Decimal {
+	return a.min
+}
+
+// Max 最大值，没有数据时返回 0。
+func (a Accumulator) Max() decimal.Decimal {
+	return a.max
+}
+
+// deviation 返回离差平方和 Σ(x - mean)² = Σx² - (Σx)² / n。
+func (a Accumulator) deviation() decimal.Decimal {
+	n := decimal.NewFromInt(a.count)
+	return a.sumSq.Sub(a.sum.Mul(a.sum).DivRound(n, statsPrecision*2))
+}
+
+// VariancePopulation 总体方差，没有数据时返回 0。
+func (a Accumulator) VariancePopulation() decimal.Decimal {
+	if a.count == 0 {
+		return decimal.Zero
+	}
+	return a.deviation().DivRound(decimal.NewFromInt(a.count), statsPrecision)
+}
+
+// VarianceSample 样本方差，少于 2 个数据时返回 0。
+func (a Accumulator) VarianceSample() decimal.Decimal {
+	if a.count < 2 {
+		return decimal.Zero
+	}
+	return a.deviation().DivRound(decimal.NewFromInt(a.count-1), statsPrecision)
+}
+
+// StdDevPopulation 总体标准差。
+func (a Accumulator) StdDevPopulation() decimal.Decimal {
+	return sqrt(a.VariancePopulation())
+}
+
+// StdDevSample 样本标准差。
+func (a Accumulator) StdDevSample() decimal.Decimal {
+	return sqrt(a.VarianceSample())
+}
+
+// SumBy 按 key 分组对 value 求和，如按部门汇总金额。
+func SumBy[T any, K comparable](items []T, key func(T) K, value func(T) decimal.Decimal) map[K]decimal.Decimal {
+	out := make(map[K]decimal.Decimal)
+	for _, item := range items {
+		k := key(item)
+		out[k] = out[k].Add(value(item))
+	}
+	return out
+}
+
+// Group 分组统计结果，嵌入的 Accumulator 提供该组的个数、合计、均值等。
+type Group[K comparable] struct {
+	Key K
+	Accumulator
+}
+
+// GroupBy 按 key 分组统计 value，结果按各组首次出现的顺序排列，便于生成报表。
+func GroupBy[T any, K comparable](items []T, key func(T) K, value func(T) decimal.Decimal) []Group[K] {
+	var groups []Group[K]
+	index := make(map[K]int)
+	for _, item := range items {
+		k := key(item)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Group[K]{Key: k})
+		}
+		groups[i].Add(value(item))
+	}
+	return groups
+}
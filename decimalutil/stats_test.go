@@ -0,0 +1,127 @@
+package decimalutil
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedianPercentile(t *testing.T) {
+	as := assert.New(t)
+	as.Equal("2.5", Median(decs("1", "3", "2", "4")...).String())
+	as.Equal("3", Median(decs("5", "1", "3")...).String())
+	as.True(Median().IsZero())
+
+	values := decs("10", "9", "8", "7", "6", "5", "4", "3", "2", "1")
+	for method, want := range map[PercentileMethod]string{
+		PercentileLinear:   "9.1",
+		PercentileLower:    "9",
+		PercentileHigher:   "10",
+		PercentileNearest:  "9",
+		PercentileMidpoint: "9.5",
+	} {
+		as.Equal(want, Percentile(values, dec("0.9"), method).String(), method)
+	}
+	as.Equal("10", values[0].String(), "不修改原切片")
+	as.Equal("1", Percentile(values, decimal.Zero, PercentileLinear).String())
+	as.Equal("10", Percentile(values, one, PercentileLinear).String())
+
+	// 距离相同时取下标为偶数者
+	as.Equal("1", Percentile(decs("1", "2", "3"), dec("0.25"), PercentileNearest).String())
+	as.Equal("3", Percentile(decs("1", "2", "3"), dec("0.75"), PercentileNearest).String())
+	as.Panics(func() { Percentile(values, dec("1.5"), PercentileLinear) })
+}
+
+func TestModeWeightedAverage(t *testing.T) {
+	as := assert.New(t)
+	as.Equal(decs("2"), Mode(decs("1", "2", "2.00", "3")...))
+	modes := Mode(decs("3", "1", "3", "1.0", "2")...)
+	as.Len(modes, 2)
+	as.Equal("1", modes[0].String())
+	as.Equal("3", modes[1].String())
+	as.Nil(Mode())
+
+	as.Equal("17.5", WeightedAverage(decs("10", "20"), decs("1", "3")).String())
+	as.Equal("0.3333333333333333", WeightedAverage(decs("1", "0", "0"), decs("1", "1", "1")).String())
+	as.True(WeightedAverage(nil, nil).IsZero())
+	as.Panics(func() { WeightedAverage(decs("1"), decs("1", "2")) })
+	as.Panics(func() { WeightedAverage(decs("1"), decs("0")) })
+}
+
+func TestVarianceStdDev(t *testing.T) {
+	as := assert.New(t)
+	values := decs("2", "4", "4", "4", "5", "5", "7", "9")
+	as.Equal("4", VariancePopulation(values...).String())
+	as.Equal("2", StdDevPopulation(values...).String())
+	as.Equal("4.5714285714285714", VarianceSample(values...).String())
+
+	// Excel STDEV.S / STDEV.P 示例
+	breaking := decs("1345", "1301", "1368", "1322", "1310", "1370", "1318", "1350", "1303", "1299")
+	as.Equal("27.46391572", StdDevSample(breaking...).StringFixed(8))
+	as.Equal("26.05455814", StdDevPopulation(breaking...).StringFixed(8))
+
+	as.True(VarianceSample(dec("1")).IsZero())
+	as.True(StdDevPopulation().IsZero())
+}
+
+func TestAccumulator(t *testing.T) {
+	as := assert.New(t)
+	var acc Accumulator
+	as.True(acc.Mean().IsZero())
+	acc.Add(decs("2", "4", "4", "4")...)
+
+	var other Accumulator
+	other.Add(decs("5", "5", "7", "9", "-1")...)
+	var empty Accumulator
+	acc.Merge(empty)
+	acc.Merge(other)
+
+	as.Equal(int64(9), acc.Count())
+	as.Equal("39", acc.Sum().String())
+	as.Equal("-1", acc.Min().String())
+	as.Equal("9", acc.Max().String())
+	as.Equal("4.3333333333333333", acc.Mean().String())
+	all := decs("2", "4", "4", "4", "5", "5", "7", "9", "-1")
+	as.True(VarianceSample(all...).Equal(acc.VarianceSample()))
+	as.Equal("8", acc.VarianceSample().String())
+
+	// 大量小额数据累加不丢精度
+	var big Accumulator
+	cent := dec("0.01")
+	for range 100000 {
+		big.Add(cent)
+	}
+	as.Equal("1000", big.Sum().String())
+	as.Equal("0.01", big.Mean().String())
+	as.True(big.VariancePopulation().IsZero())
+}
+
+func TestGroupBy(t *testing.T) {
+	as := assert.New(t)
+	type order struct {
+		Dept   string
+		Amount decimal.Decimal
+	}
+	orders := []order{
+		{"sales", dec("100.10")},
+		{"ops", dec("20")},
+		{"sales", dec("0.20")},
+		{"hr", dec("5")},
+		{"ops", dec("30")},
+	}
+	key := func(o order) string { return o.Dept }
+	amount := func(o order) decimal.Decimal { return o.Amount }
+
+	sums := SumBy(orders, key, amount)
+	as.Len(sums, 3)
+	as.Equal("100.3", sums["sales"].String())
+	as.Equal("50", sums["ops"].String())
+
+	groups := GroupBy(orders, key, amount)
+	as.Equal([]string{"sales", "ops", "hr"}, []string{groups[0].Key, groups[1].Key, groups[2].Key})
+	as.Equal(int64(2), groups[1].Count())
+	as.Equal("25", groups[1].Mean().String())
+	as.Equal("100.1", groups[0].Max().String())
+	as.Empty(GroupBy(nil, key, amount))
+}
@@ -0,0 +1,106 @@
+package moneyutil
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidAllocation 分摊参数非法，如权重为空、为负或全为 0。
+var ErrInvalidAllocation = errors.New("非法的分摊参数")
+
+// AllocStrategy 按比例分摊后，舍入产生的零头如何分配。
+type AllocStrategy int
+
+const (
+	// AllocLargestRemainder 各项先向下取整，零头按余数从大到小每项一个最小单位（最大余额法），余数相同时靠前者优先
+	AllocLargestRemainder AllocStrategy = iota
+	// AllocFirstN 各项先向下取整，零头从第一项起每项一个最小单位
+	AllocFirstN
+	// AllocLastAbsorbs 除最后一项外各项向下取整，最后一项取差额，因此最后一项与 total 同号
+	AllocLastAbsorbs
+)
+
+// Allocate 将 total 按 weights 的比例分摊到各项，结果保留 precision 位小数且总和恰好等于 total，
+// 如按各商品金额分摊订单优惠。total 为负时各项均为负；权重为 0 的项分得 0（AllocLastAbsorbs 的最后一项除外）。
+// total 的小数位不能超过 precision，权重不能为负且不能全为 0。
+func Allocate[W any](total any, weights []W, precision int32, strategy AllocStrategy) ([]decimal.Decimal, error) {
+	t := toDecimal(total)
+	if !t.Equal(t.Truncate(precision)) {
+		return nil, fmt.Errorf("%w: 总额 %s 的小数位超过精度 %d", ErrInvalidAllocation, t, precision)
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("%w: 权重为空", ErrInvalidAllocation)
+	}
+	ws := make([]decimal.Decimal, len(weights))
+	sum := decimal.Zero
+	for i, w := range weights {
+		ws[i] = toDecimal(w)
+		if ws[i].IsNegative() {
+			return nil, fmt.Errorf("%w: 第 %d 项权重 %s 为负", ErrInvalidAllocation, i, ws[i])
+		}
+		sum = sum.Add(ws[i])
+	}
+	if sum.IsZero() {
+		return nil, fmt.Errorf("%w: 权重全为 0", ErrInvalidAllocation)
+	}
+
+	neg := t.IsNegative()
+	t = t.Abs()
+	unit := decimal.New(1, -precision)
+	parts := make([]decimal.Decimal, len(ws))
+	rems := make([]decimal.Decimal, len(ws))
+	allocated := decimal.Zero
+	for i, w := range ws {
+		if strategy == AllocLastAbsorbs && i == len(ws)-1 {
+			break
+		}
+		parts[i], rems[i] = t.Mul(w).QuoRem(sum, precision)
+		allocated = allocated.Add(parts[i])
+	}
+
+	switch strategy {
+	case AllocLastAbsorbs:
+		parts[len(parts)-1] = t.Sub(allocated)
+	case AllocFirstN, AllocLargestRemainder:
+		order := make([]int, 0, len(ws))
+		for i, w := range ws {
+			if w.IsPositive() {
+				order = append(order, i)
+			}
+		}
+		if strategy == AllocLargestRemainder {
+			slices.SortStableFunc(order, func(a, b int) int { return rems[b].Cmp(rems[a]) })
+		}
+		left := t.Sub(allocated).Shift(precision).IntPart()
+		for k := int64(0); k < left; k++ {
+			i := order[k%int64(len(order))]
+			parts[i] = parts[i].Add(unit)
+		}
+	default:
+		return nil, fmt.Errorf("%w: 未知策略 %d", ErrInvalidAllocation, strategy)
+	}
+
+	for i := range parts {
+		if neg {
+			parts[i] = parts[i].Neg()
+		}
+		parts[i] = parts[i].Round(precision)
+	}
+	return parts, nil
+}
+
+// SplitEvenly 将 total 平均分成 n 份，保留 precision 位小数，零头从第一份起每份一个最小单位，
+// 如 100 分 3 份为 33.34、33.33、33.33。
+func SplitEvenly(total any, n int, precision int32) ([]decimal.Decimal, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: 份数 %d 必须为正", ErrInvalidAllocation, n)
+	}
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return Allocate(total, weights, precision, AllocFirstN)
+}
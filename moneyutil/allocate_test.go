@@ -0,0 +1,97 @@
+package moneyutil
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decs(vals ...string) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(vals))
+	for i, v := range vals {
+		out[i] = decimal.RequireFromString(v)
+	}
+	return out
+}
+
+func assertDecs(t *testing.T, want []decimal.Decimal, got []decimal.Decimal) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.True(t, want[i].Equal(got[i]), "第 %d 项: want %s, got %s", i, want[i], got[i])
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	// 10 元优惠按 3 件商品金额分摊：3.333.. / 3.333.. / 3.333..
+	got, err := Allocate("10", []int{1, 1, 1}, 2, AllocLargestRemainder)
+	require.NoError(t, err)
+	assertDecs(t, decs("3.34", "3.33", "3.33"), got)
+
+	// 恰好整除时没有零头
+	got, err = Allocate("1", []string{"50", "30", "20"}, 2, AllocLargestRemainder)
+	require.NoError(t, err)
+	assertDecs(t, decs("0.5", "0.3", "0.2"), got)
+
+	// 余数分别为 0.5 / 0.67 / 0.83 个单位：最大余额法给后两项，FirstN 给前两项
+	got, err = Allocate("0.05", []int{3, 2, 1, 0}, 2, AllocLargestRemainder)
+	require.NoError(t, err)
+	assertDecs(t, decs("0.02", "0.02", "0.01", "0"), got)
+
+	got, err = Allocate("0.05", []int{3, 2, 1, 0}, 2, AllocFirstN)
+	require.NoError(t, err)
+	assertDecs(t, decs("0.03", "0.02", "0", "0"), got)
+
+	got, err = Allocate("10", []int{1, 1, 1}, 2, AllocLastAbsorbs)
+	require.NoError(t, err)
+	assertDecs(t, decs("3.33", "3.33", "3.34"), got)
+
+	// 其余各项向下取整，最后一项不会因四舍五入累计超额而变为负数
+	got, err = Allocate("0.10", []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, 2, AllocLastAbsorbs)
+	require.NoError(t, err)
+	assertDecs(t, decs("0.00", "0.00", "0.00", "0.00", "0.00", "0.00", "0.00", "0.00", "0.00", "0.00", "0.00", "0.10"), got)
+
+	got, err = Allocate("-10", []int{1, 1, 1}, 2, AllocLargestRemainder)
+	require.NoError(t, err)
+	assertDecs(t, decs("-3.34", "-3.33", "-3.33"), got)
+
+	got, err = Allocate(100, []decimal.Decimal{decimal.NewFromFloat(0.7), decimal.NewFromFloat(0.3)}, 0, AllocLargestRemainder)
+	require.NoError(t, err)
+	assertDecs(t, decs("70", "30"), got)
+
+	for _, s := range []AllocStrategy{AllocLargestRemainder, AllocFirstN, AllocLastAbsorbs} {
+		got, err := Allocate("999.99", []string{"13.7", "0.01", "250", "3", "88.8"}, 2, s)
+		require.NoError(t, err)
+		assert.True(t, decimal.RequireFromString("999.99").Equal(decimal.Sum(decimal.Zero, got...)), s)
+	}
+}
+
+func TestAllocateInvalid(t *testing.T) {
+	as := assert.New(t)
+	_, err := Allocate("10.005", []int{1}, 2, AllocFirstN)
+	as.ErrorIs(err, ErrInvalidAllocation)
+	_, err = Allocate("10", []int{}, 2, AllocFirstN)
+	as.ErrorIs(err, ErrInvalidAllocation)
+	_, err = Allocate("10", []int{1, -1}, 2, AllocFirstN)
+	as.ErrorIs(err, ErrInvalidAllocation)
+	_, err = Allocate("10", []int{0, 0}, 2, AllocFirstN)
+	as.ErrorIs(err, ErrInvalidAllocation)
+	_, err = SplitEvenly("10", 0, 2)
+	as.ErrorIs(err, ErrInvalidAllocation)
+}
+
+func TestSplitEvenly(t *testing.T) {
+	got, err := SplitEvenly(100, 3, 2)
+	require.NoError(t, err)
+	assertDecs(t, decs("33.34", "33.33", "33.33"), got)
+
+	got, err = SplitEvenly("-0.05", 3, 2)
+	require.NoError(t, err)
+	assertDecs(t, decs("-0.02", "-0.02", "-0.01"), got)
+
+	got, err = SplitEvenly(7, 2, 0)
+	require.NoError(t, err)
+	assertDecs(t, decs("4", "3"), got)
+}
@@ -0,0 +1,212 @@
+package moneyutil
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/lontten/lutil/dateutil"
+	"github.com/lontten/lutil/decimalutil"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidLoan 贷款参数非法，如本金不为正、期数不为正或利率为负。
+var ErrInvalidLoan = errors.New("非法的贷款参数")
+
+// AmortizeMethod 还款方式。
+type AmortizeMethod int
+
+const (
+	// EqualInstallment 等额本息：每期还款额相同，前期利息占比高
+	EqualInstallment AmortizeMethod = iota
+	// EqualPrincipal 等额本金：每期归还相同本金，利息随余额递减
+	EqualPrincipal
+	// InterestOnly 先息后本：每期只付利息，最后一期归还全部本金
+	InterestOnly
+)
+
+// String 返回还款方式的中文名。
+func (m AmortizeMethod) String() string {
+	switch m {
+	case EqualInstallment:
+		return "等额本息"
+	case EqualPrincipal:
+		return "等额本金"
+	case InterestOnly:
+		return "先息后本"
+	}
+	return fmt.Sprintf("AmortizeMethod(%d)", int(m))
+}
+
+// AmortizeRow 还款计划中的一期。
+type AmortizeRow struct {
+	Period    int             // 期次，从 1 开始
+	Date      types.LocalDate // 还款日，未设置放款日时为零值
+	Payment   decimal.Decimal // 本期还款额 = Principal + Interest
+	Principal decimal.Decimal // 本期归还本金
+	Interest  decimal.Decimal // 本期利息
+	Balance   decimal.Decimal // 本期还款后的剩余本金
+}
+
+// AmortizeSchedule 还款计划。各期本金之和恰好等于 Principal，各期还款额之和恰好等于 TotalPayment。
+type AmortizeSchedule struct {
+	Method        AmortizeMethod
+	Principal     decimal.Decimal
+	Fee           decimal.Decimal // 放款时一次性收取的费用，计入 APR
+	TotalPayment  decimal.Decimal
+	TotalInterest decimal.Decimal
+	Rows          []AmortizeRow
+
+	periodMonths int
+}
+
+type amortizeOpts struct {
+	start        types.LocalDate
+	periodMonths int
+	precision    int32
+	fee          decimal.Decimal
+}
+
+// AmortizeOpts 创建还款计划选项，默认按月还款、精度 2 位小数、无放款日与手续费。
+func AmortizeOpts() *amortizeOpts {
+	return &amortizeOpts{periodMonths: 1, precision: 2}
+}
+
+// Start 设置放款日，第 i 期还款日为放款日加 i 个还款周期，目标月没有对应日时取月末。
+func (o *amortizeOpts) Start(d types.LocalDate) *amortizeOpts {
+	o.start = d
+	return o
+}
+
+// PeriodMonths 设置每期间隔的月数，如 3 为按季还款，期利率为年利率 × n / 12。
+func (o *amortizeOpts) PeriodMonths(n int) *amortizeOpts {
+	o.periodMonths = n
+	return o
+}
+
+// Precision 设置金额精度。
+func (o *amortizeOpts) Precision(p int32) *amortizeOpts {
+	o.precision = p
+	return o
+}
+
+// Fee 设置放款时一次性收取的手续费，不影响还款计划，只影响 APR 与 IRR。
+func (o *amortizeOpts) Fee(fee any) *amortizeOpts {
+	o.fee = toDecimal(fee)
+	return o
+}
+
+func resolveAmortizeOpts(opts ...*amortizeOpts) *amortizeOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return AmortizeOpts()
+	}
+	return opts[0]
+}
+
+// Amortize 生成 periods 期的还款计划，annualRate 为名义年利率（如 0.06 表示 6%）。
+// 每期利息按剩余本金 × 期利率舍入到 precision；等额本息的每期还款额与等额本金的每期本金同样先舍入，
+// 舍入产生的差额由最后一期吸收，使各期本金之和恰好等于本金。
+func Amortize(principal, annualRate any, periods int, method AmortizeMethod, opts ...*amortizeOpts) (AmortizeSchedule, error) {
+	o := resolveAmortizeOpts(opts...)
+	p, rate := toDecimal(principal), toDecimal(annualRate)
+	switch {
+	case !p.IsPositive():
+		return AmortizeSchedule{}, fmt.Errorf("%w: 本金 %s 不为正", ErrInvalidLoan, p)
+	case !p.Equal(p.Truncate(o.precision)):
+		return AmortizeSchedule{}, fmt.Errorf("%w: 本金 %s 的小数位超过精度 %d", ErrInvalidLoan, p, o.precision)
+	case periods <= 0:
+		return AmortizeSchedule{}, fmt.Errorf("%w: 期数 %d 不为正", ErrInvalidLoan, periods)
+	case rate.IsNegative():
+		return AmortizeSchedule{}, fmt.Errorf("%w: 利率 %s 为负", ErrInvalidLoan, rate)
+	case o.periodMonths <= 0:
+		return AmortizeSchedule{}, fmt.Errorf("%w: 还款间隔 %d 个月不为正", ErrInvalidLoan, o.periodMonths)
+	case method < EqualInstallment || method > InterestOnly:
+		return AmortizeSchedule{}, fmt.Errorf("%w: 未知的还款方式 %d", ErrInvalidLoan, int(method))
+	}
+
+	r := rate.Mul(decimal.NewFromInt(int64(o.periodMonths))).Div(decimal.NewFromInt(12))
+	n := decimal.NewFromInt(int64(periods))
+	var installment, principalPart decimal.Decimal
+	switch method {
+	case EqualInstallment:
+		if r.IsZero() {
+			installment = p.DivRound(n, o.precision)
+		} else {
+			// 每期还款额 = P × r × (1+r)^n / ((1+r)^n - 1)
+			f := decimal.NewFromInt(1).Add(r).Pow(n)
+			installment = p.Mul(r).Mul(f).DivRound(f.Sub(decimal.NewFromInt(1)), o.precision)
+		}
+	case EqualPrincipal:
+		principalPart = p.DivRound(n, o.precision)
+	}
+
+	s := AmortizeSchedule{Method: method, Principal: p, Fee: o.fee, Rows: make([]AmortizeRow, periods), periodMonths: o.periodMonths}
+	balance := p
+	for i := range periods {
+		row := AmortizeRow{Period: i + 1, Interest: balance.Mul(r).Round(o.precision)}
+		if !o.start.IsZero() {
+			row.Date = dateutil.AddMonthsClamped(o.start, (i+1)*o.periodMonths)
+		}
+		switch {
+		case i == periods-1:
+			row.Principal = balance
+		case method == EqualInstallment:
+			row.Principal = decimal.Min(installment.Sub(row.Interest), balance)
+		case method == EqualPrincipal:
+			row.Principal = decimal.Min(principalPart, balance)
+		default:
+			row.Principal = decimal.Zero
+		}
+		row.Payment = row.Principal.Add(row.Interest)
+		balance = balance.Sub(row.Principal)
+		row.Balance = balance
+		s.Rows[i] = row
+		s.TotalPayment = s.TotalPayment.Add(row.Payment)
+		s.TotalInterest = s.TotalInterest.Add(row.Interest)
+	}
+	return s, nil
+}
+
+// IRR 返回还款计划的期内部收益率：使放款净额（本金减手续费）等于各期还款额折现之和的期利率。
+// 零值或无解（如手续费不小于本金）时返回包装了 ErrInvalidLoan 的错误。
+func (s AmortizeSchedule) IRR() (decimal.Decimal, error) {
+	if len(s.Rows) == 0 || s.periodMonths <= 0 {
+		return decimal.Zero, fmt.Errorf("%w: 还款计划为空，应由 Amortize 生成", ErrInvalidLoan)
+	}
+	flows := make([]decimal.Decimal, len(s.Rows)+1)
+	flows[0] = s.Principal.Sub(s.Fee).Neg()
+	for i, row := range s.Rows {
+		flows[i+1] = row.Payment
+	}
+	r, err := decimalutil.IRR(flows, decimalutil.SolveOpts().Guess(decimal.New(1, -2)))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%w: %w", ErrInvalidLoan, err)
+	}
+	return r.Round(irrPrecision), nil
+}
+
+// APR 返回年化利率（名义），等于期内部收益率 × 每年期数。有手续费时高于合同利率。
+func (s AmortizeSchedule) APR() (decimal.Decimal, error) {
+	irr, err := s.IRR()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return irr.Mul(s.periodsPerYear()).Round(irrPrecision), nil
+}
+
+// EffectiveAnnualRate 返回实际年利率，即按期内部收益率复利一年：(1 + IRR)^每年期数 - 1。
+func (s AmortizeSchedule) EffectiveAnnualRate() (decimal.Decimal, error) {
+	irr, err := s.IRR()
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromInt(1).Add(irr).Pow(s.periodsPerYear()).Sub(decimal.NewFromInt(1)).Round(irrPrecision), nil
+}
+
+// periodsPerYear 每年还款期数，调用方须保证 periodMonths 为正。
+func (s AmortizeSchedule) periodsPerYear() decimal.Decimal {
+	return decimal.NewFromInt(12).Div(decimal.NewFromInt(int64(s.periodMonths)))
+}
+
+// irrPrecision IRR、APR 等利率结果保留的小数位。
+const irrPrecision = 10
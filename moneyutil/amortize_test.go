@@ -0,0 +1,131 @@
+package moneyutil
+
+import (
+	"testing"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertScheduleBalance(t *testing.T, s AmortizeSchedule) {
+	t.Helper()
+	principal, payment, interest := decimal.Zero, decimal.Zero, decimal.Zero
+	for _, r := range s.Rows {
+		principal = principal.Add(r.Principal)
+		payment = payment.Add(r.Payment)
+		interest = interest.Add(r.Interest)
+		assert.True(t, r.Payment.Equal(r.Principal.Add(r.Interest)), "第 %d 期", r.Period)
+	}
+	assert.True(t, s.Principal.Equal(principal), "本金 %s != %s", s.Principal, principal)
+	assert.True(t, s.TotalPayment.Equal(payment))
+	assert.True(t, s.TotalInterest.Equal(interest))
+	assert.True(t, s.Rows[len(s.Rows)-1].Balance.IsZero())
+}
+
+// rateString 将 IRR/APR 等带错误的返回值转为字符串，出错时测试失败。
+func rateString(t *testing.T) func(decimal.Decimal, error) string {
+	return func(d decimal.Decimal, err error) string {
+		t.Helper()
+		require.NoError(t, err)
+		return d.String()
+	}
+}
+
+func TestAmortizeEqualInstallment(t *testing.T) {
+	as := assert.New(t)
+	s, err := Amortize(100000, "0.06", 12, EqualInstallment)
+	require.NoError(t, err)
+	assertScheduleBalance(t, s)
+	as.Equal("8606.64", s.Rows[0].Payment.String())
+	as.Equal("500", s.Rows[0].Interest.String())
+	as.Equal("91893.36", s.Rows[0].Balance.String())
+	// 最后一期吸收舍入差额
+	as.Equal("8606.69", s.Rows[11].Payment.String())
+	as.Equal("3279.73", s.TotalInterest.String())
+	as.True(s.Rows[0].Date.IsZero())
+
+	// 30 年房贷，年利率 4.9%
+	s, err = Amortize(1000000, "0.049", 360, EqualInstallment)
+	require.NoError(t, err)
+	assertScheduleBalance(t, s)
+	as.Equal("5307.27", s.Rows[0].Payment.String())
+	as.Equal("910615.12", s.TotalInterest.String())
+
+	s, err = Amortize(1000, 0, 3, EqualInstallment)
+	require.NoError(t, err)
+	as.Equal([]string{"333.33", "333.33", "333.34"}, []string{s.Rows[0].Payment.String(), s.Rows[1].Payment.String(), s.Rows[2].Payment.String()})
+	as.True(s.TotalInterest.IsZero())
+}
+
+func TestAmortizeOtherMethods(t *testing.T) {
+	as := assert.New(t)
+	start, _ := types.LocalDateParse("2024-01-31")
+	s, err := Amortize(120000, "0.06", 12, EqualPrincipal, AmortizeOpts().Start(start))
+	require.NoError(t, err)
+	assertScheduleBalance(t, s)
+	as.Equal("10600", s.Rows[0].Payment.String())
+	as.Equal("10050", s.Rows[11].Payment.String())
+	as.Equal("3900", s.TotalInterest.String())
+	as.Equal("2024-02-29", s.Rows[0].Date.String())
+	as.Equal("2025-01-31", s.Rows[11].Date.String())
+
+	s, err = Amortize(100000, "0.08", 4, InterestOnly, AmortizeOpts().PeriodMonths(3))
+	require.NoError(t, err)
+	assertScheduleBalance(t, s)
+	as.Equal("2000", s.Rows[0].Payment.String())
+	as.True(s.Rows[2].Principal.IsZero())
+	as.Equal("102000", s.Rows[3].Payment.String())
+	rate := rateString(t)
+	as.Equal("0.08", rate(s.APR()))
+	as.Equal("0.08243216", rate(s.EffectiveAnnualRate()))
+
+	as.Equal("先息后本", InterestOnly.String())
+}
+
+func TestAmortizeAPR(t *testing.T) {
+	as := assert.New(t)
+	rate := rateString(t)
+	s, err := Amortize(120000, "0.06", 12, EqualPrincipal)
+	require.NoError(t, err)
+	as.Equal("0.005", rate(s.IRR()))
+	as.Equal("0.06", rate(s.APR()))
+
+	// 收取 1000 元手续费后实际年化明显高于合同利率
+	s, err = Amortize(100000, "0.06", 12, EqualInstallment, AmortizeOpts().Fee(1000))
+	require.NoError(t, err)
+	as.Equal("0.0788615124", rate(s.APR()))
+	as.Equal("0.0065717927", rate(s.IRR()))
+
+	// 无解时返回错误而不是 0%
+	s, err = Amortize(1000, "0.06", 12, EqualInstallment, AmortizeOpts().Fee(1000))
+	require.NoError(t, err)
+	_, err = s.APR()
+	as.ErrorIs(err, ErrInvalidLoan)
+	_, err = s.EffectiveAnnualRate()
+	as.ErrorIs(err, ErrInvalidLoan)
+
+	// 零值不 panic
+	_, err = AmortizeSchedule{}.APR()
+	as.ErrorIs(err, ErrInvalidLoan)
+	_, err = AmortizeSchedule{}.IRR()
+	as.ErrorIs(err, ErrInvalidLoan)
+}
+
+func TestAmortizeInvalid(t *testing.T) {
+	as := assert.New(t)
+	for _, f := range []func() (AmortizeSchedule, error){
+		func() (AmortizeSchedule, error) { return Amortize(0, "0.06", 12, EqualInstallment) },
+		func() (AmortizeSchedule, error) { return Amortize("100.001", "0.06", 12, EqualInstallment) },
+		func() (AmortizeSchedule, error) { return Amortize(100, "0.06", 0, EqualInstallment) },
+		func() (AmortizeSchedule, error) { return Amortize(100, "-0.01", 12, EqualInstallment) },
+		func() (AmortizeSchedule, error) { return Amortize(100, "0.06", 12, AmortizeMethod(9)) },
+		func() (AmortizeSchedule, error) {
+			return Amortize(100, "0.06", 12, EqualInstallment, AmortizeOpts().PeriodMonths(0))
+		},
+	} {
+		_, err := f()
+		as.ErrorIs(err, ErrInvalidLoan)
+	}
+}
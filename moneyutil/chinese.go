@@ -0,0 +1,207 @@
+package moneyutil
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidChineseAmount 非法的中文大写金额。
+var ErrInvalidChineseAmount = errors.New("非法的中文大写金额")
+
+var (
+	upperDigits = []rune("零壹贰叁肆伍陆柒捌玖")
+	upperUnits  = []string{"仟", "佰", "拾", ""}
+	// maxChineseUpper 支持的最大金额（不含），即 1 万亿亿。
+	maxChineseUpper = decimal.New(1, 16)
+)
+
+// ToChineseUpper 将金额转换为中文大写，如 12345.67 为 壹万贰仟叁佰肆拾伍元陆角柒分。
+// 金额先四舍五入到分；整数金额以“整”结尾，角位为 0 而分位不为 0 时写“零”，负数前缀“负”。
+// 绝对值不能达到 1 亿亿（10^16）。
+func ToChineseUpper(amount any) (string, error) {
+	d := toDecimal(amount).Round(2)
+	if d.Abs().GreaterThanOrEqual(maxChineseUpper) {
+		return "", fmt.Errorf("%w: %s 超出范围", ErrInvalidChineseAmount, d)
+	}
+	var b strings.Builder
+	if d.IsNegative() {
+		b.WriteString("负")
+		d = d.Neg()
+	}
+	cents := d.Shift(2).IntPart()
+	yuan, jiao, fen := cents/100, int(cents/10%10), int(cents%10)
+
+	if yuan > 0 {
+		b.WriteString(chineseInteger(yuan))
+		b.WriteString("元")
+	}
+	switch {
+	case jiao == 0 && fen == 0:
+		if yuan == 0 {
+			b.WriteString("零元")
+		}
+		b.WriteString("整")
+	case jiao == 0:
+		if yuan > 0 {
+			b.WriteRune(upperDigits[0])
+		}
+		b.WriteRune(upperDigits[fen])
+		b.WriteString("分")
+	default:
+		b.WriteRune(upperDigits[jiao])
+		b.WriteString("角")
+		if fen > 0 {
+			b.WriteRune(upperDigits[fen])
+			b.WriteString("分")
+		}
+	}
+	return b.String(), nil
+}
+
+// chineseInteger 将正整数转换为大写：亿以上部分递归转换；亿以下按四位一节，
+// 节内或节间的连续 0 只写一个“零”，节末的 0 不写。
+func chineseInteger(n int64) string {
+	if n >= 100000000 {
+		high, low := n/100000000, n%100000000
+		s := chineseInteger(high) + "亿"
+		switch {
+		case low == 0:
+			return s
+		case low < 10000000:
+			s += string(upperDigits[0])
+		}
+		return s + chineseInteger(low)
+	}
+	var b strings.Builder
+	zero := false
+	for i, sec := range []int64{n / 10000, n % 10000} {
+		if sec == 0 {
+			zero = b.Len() > 0
+			continue
+		}
+		for j, div := range []int64{1000, 100, 10, 1} {
+			digit := sec / div % 10
+			if digit == 0 {
+				zero = zero || b.Len() > 0
+				continue
+			}
+			if zero {
+				b.WriteRune(upperDigits[0])
+				zero = false
+			}
+			b.WriteRune(upperDigits[digit])
+			b.WriteString(upperUnits[j])
+		}
+		if i == 0 {
+			b.WriteString("万")
+		}
+	}
+	return b.String()
+}
+
+// ParseChineseUpper 解析中文大写金额，ToChineseUpper 的逆运算；
+// 兼容“圆”“正”以及省略“零”的写法，如 壹仟元叁角、拾元整。
+func ParseChineseUpper(s string) (decimal.Decimal, error) {
+	orig := s
+	s = strings.TrimSpace(s)
+	neg := strings.HasPrefix(s, "负")
+	s = strings.TrimPrefix(s, "负")
+	s = strings.TrimRight(s, "整正")
+	s = strings.ReplaceAll(s, "圆", "元")
+	fail := func() (decimal.Decimal, error) {
+		return decimal.Decimal{}, fmt.Errorf("%w: %q", ErrInvalidChineseAmount, orig)
+	}
+	if s == "" {
+		return fail()
+	}
+
+	intPart, frac, hasYuan := strings.Cut(s, "元")
+	if !hasYuan {
+		intPart, frac = "", s
+	}
+	yuan, ok := parseChineseInteger(intPart)
+	if !ok || hasYuan && intPart == "" || !hasYuan && frac == "" {
+		return fail()
+	}
+	cents := decimal.NewFromInt(yuan).Shift(2)
+	if cents.Shift(-2).GreaterThanOrEqual(maxChineseUpper) {
+		return fail()
+	}
+
+	// 角分部分：[零]X角[X分] 或 [零]X分
+	frac = strings.TrimPrefix(frac, "零")
+	for _, unit := range []struct {
+		name  string
+		shift int32
+	}{{"角", 1}, {"分", 0}} {
+		r := []rune(frac)
+		if len(r) >= 2 && string(r[1]) == unit.name {
+			digit := slices.Index(upperDigits, r[0])
+			if digit < 0 {
+				return fail()
+			}
+			cents = cents.Add(decimal.New(int64(digit), unit.shift))
+			frac = string(r[2:])
+		}
+	}
+	if frac != "" {
+		return fail()
+	}
+	d := cents.Shift(-2)
+	if neg {
+		d = d.Neg()
+	}
+	return d, nil
+}
+
+// parseChineseInteger 解析整数部分，空串为 0。
+// 结构须与 chineseInteger 一致：节内 仟、佰、拾 依次递减，每个亿节至多一个万，亿至多一个。
+func parseChineseInteger(s string) (int64, bool) {
+	var total, cur, sec, num int64
+	lastUnit := int64(10000) // 当前节内上一个 仟/佰/拾 的单位
+	hasWan, hasYi := false, false
+	for _, r := range s {
+		if d := slices.Index(upperDigits, r); d >= 0 {
+			if num != 0 {
+				return 0, false // 连续数字，如 壹贰、贰零伍
+			}
+			num = int64(d)
+			continue
+		}
+		switch r {
+		case '拾', '佰', '仟':
+			unit := map[rune]int64{'拾': 10, '佰': 100, '仟': 1000}[r]
+			if unit >= lastUnit {
+				return 0, false
+			}
+			if num == 0 {
+				if r != '拾' {
+					return 0, false
+				}
+				num = 1 // 拾元 即 壹拾元
+			}
+			sec += num * unit
+			lastUnit = unit
+		case '万':
+			if hasWan || sec+num == 0 {
+				return 0, false
+			}
+			cur += (sec + num) * 10000
+			sec, lastUnit, hasWan = 0, 10000, true
+		case '亿':
+			if hasYi || cur+sec+num == 0 {
+				return 0, false
+			}
+			total = (cur + sec + num) * 100000000
+			cur, sec, lastUnit, hasWan, hasYi = 0, 0, 10000, false, true
+		default:
+			return 0, false
+		}
+		num = 0
+	}
+	return total + cur + sec + num, true
+}
@@ -0,0 +1,83 @@
+package moneyutil
+
+import (
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed data/iso4217.csv
+var currencyFS embed.FS
+
+// ErrUnknownCurrency 未知的币种代码。
+var ErrUnknownCurrency = errors.New("未知币种")
+
+// Currency ISO 4217 币种信息。
+type Currency struct {
+	Code       string // 字母代码，如 CNY
+	Numeric    string // 数字代码，如 156
+	MinorUnits int32  // 辅币位数，如 CNY 为 2、JPY 为 0、KWD 为 3
+	Symbol     string // 货币符号，如 ¥
+	Name       string // 中文名称，如 人民币
+}
+
+var (
+	currencyOnce sync.Once
+	currencyMu   sync.RWMutex
+	currencies   map[string]Currency
+)
+
+// loadCurrencies 加载内建币种表，数据来自 data/iso4217.csv，
+// 收录 ISO 4217 现行的全部货币与基金代码；贵金属、测试代码、特别提款权等无辅币位数的代码不收录，需要时用 RegisterCurrency 注册。
+func loadCurrencies() {
+	currencyOnce.Do(func() {
+		raw, err := currencyFS.ReadFile("data/iso4217.csv")
+		if err != nil {
+			panic(err)
+		}
+		rows, err := csv.NewReader(strings.NewReader(string(raw))).ReadAll()
+		if err != nil {
+			panic(fmt.Errorf("加载内建币种数据失败: %w", err))
+		}
+		m := make(map[string]Currency, len(rows))
+		for _, row := range rows[1:] {
+			minor, err := strconv.Atoi(row[2])
+			if err != nil {
+				panic(fmt.Errorf("加载内建币种数据 %s 失败: %w", row[0], err))
+			}
+			m[row[0]] = Currency{Code: row[0], Numeric: row[1], MinorUnits: int32(minor), Symbol: row[3], Name: row[4]}
+		}
+		currencies = m
+	})
+}
+
+// LookupCurrency 按字母代码查找币种，不区分大小写。
+func LookupCurrency(code string) (Currency, bool) {
+	loadCurrencies()
+	currencyMu.RLock()
+	defer currencyMu.RUnlock()
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// RegisterCurrency 注册或覆盖币种，用于内建表未收录的币种或积分等自定义计价单位。
+func RegisterCurrency(c Currency) {
+	loadCurrencies()
+	c.Code = strings.ToUpper(c.Code)
+	currencyMu.Lock()
+	defer currencyMu.Unlock()
+	currencies[c.Code] = c
+}
+
+// currencyOf 查找币种，未知时返回 ErrUnknownCurrency。
+func currencyOf(code string) (Currency, error) {
+	c, ok := LookupCurrency(code)
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
@@ -0,0 +1,337 @@
+package moneyutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrRateNotFound 找不到币种间的汇率。
+	ErrRateNotFound = errors.New("找不到汇率")
+	// ErrInvalidRate 非法的汇率，如币种为空、汇率不为正或生效日期非法。
+	ErrInvalidRate = errors.New("非法的汇率")
+)
+
+// RateProvider 汇率来源，返回 at 当天 1 单位 from 可兑换的 to 数量。
+// 找不到汇率时应返回包装了 ErrRateNotFound 的错误，以便换算时尝试经由基准币种套算。
+type RateProvider interface {
+	Rate(from, to string, at types.LocalDate) (decimal.Decimal, error)
+}
+
+// RateFunc 将函数适配为 RateProvider，便于接入数据库或外部接口。
+type RateFunc func(from, to string, at types.LocalDate) (decimal.Decimal, error)
+
+// Rate 调用 f。
+func (f RateFunc) Rate(from, to string, at types.LocalDate) (decimal.Decimal, error) {
+	return f(from, to, at)
+}
+
+// RateTable 内存汇率表，可作为固定汇率表，也可按生效日期保存多个版本，并发安全。
+// 查询时取生效日期不晚于查询日期的最新版本；只有反向汇率时取其倒数。
+type RateTable struct {
+	mu    sync.RWMutex
+	rates map[[2]string][]rateVersion
+}
+
+type rateVersion struct {
+	since types.LocalDate // 零值表示一直有效
+	rate  decimal.Decimal
+}
+
+// NewRateTable 创建空的汇率表。
+func NewRateTable() *RateTable {
+	return &RateTable{rates: make(map[[2]string][]rateVersion)}
+}
+
+// Set 设置一直有效的汇率：1 单位 from 可兑换 rate 单位 to；币种为空或汇率不为正时返回 ErrInvalidRate。
+func (t *RateTable) Set(from, to string, rate any) error {
+	return t.SetAt(from, to, rate, types.LocalDate{})
+}
+
+// SetAt 设置自 since 当天起生效的汇率；同一币种对同一生效日期重复设置时覆盖。
+// 币种为空或汇率不为正时返回 ErrInvalidRate。
+func (t *RateTable) SetAt(from, to string, rate any, since types.LocalDate) error {
+	e, err := newRateEntry(from, to, toDecimal(rate), since)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.put(e)
+	return nil
+}
+
+// rateEntry 校验通过、待写入的一条汇率。
+type rateEntry struct {
+	key [2]string
+	v   rateVersion
+}
+
+func newRateEntry(from, to string, rate decimal.Decimal, since types.LocalDate) (rateEntry, error) {
+	if from == "" || to == "" || !rate.IsPositive() {
+		return rateEntry{}, fmt.Errorf("%w: %s/%s 汇率 %s", ErrInvalidRate, from, to, rate)
+	}
+	return rateEntry{key: [2]string{strings.ToUpper(from), strings.ToUpper(to)}, v: rateVersion{since: since, rate: rate}}, nil
+}
+
+// put 写入一条汇率，调用方须持有写锁。
+func (t *RateTable) put(e rateEntry) {
+	list := t.rates[e.key]
+	i, found := slices.BinarySearchFunc(list, e.v.since, func(v rateVersion, d types.LocalDate) int {
+		return compareSince(v.since, d)
+	})
+	if found {
+		list[i] = e.v
+	} else {
+		list = slices.Insert(list, i, e.v)
+	}
+	t.rates[e.key] = list
+}
+
+// Rate 实现 RateProvider。
+func (t *RateTable) Rate(from, to string, at types.LocalDate) (decimal.Decimal, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if r, ok := t.lookup(from, to, at); ok {
+		return r, nil
+	}
+	if r, ok := t.lookup(to, from, at); ok && !r.IsZero() {
+		return decimal.NewFromInt(1).DivRound(r, rateDivPrecision), nil
+	}
+	return decimal.Decimal{}, fmt.Errorf("%w: %s/%s 于 %s", ErrRateNotFound, from, to, at)
+}
+
+func (t *RateTable) lookup(from, to string, at types.LocalDate) (decimal.Decimal, bool) {
+	list := t.rates[[2]string{from, to}]
+	for i := len(list) - 1; i >= 0; i-- {
+		if list[i].since.IsZero() || !list[i].since.After(at) {
+			return list[i].rate, true
+		}
+	}
+	return decimal.Decimal{}, false
+}
+
+// compareSince 比较生效日期，零值最早。
+func compareSince(a, b types.LocalDate) int {
+	switch {
+	case a.IsZero() && b.IsZero():
+		return 0
+	case a.IsZero():
+		return -1
+	case b.IsZero():
+		return 1
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// RateRecord 汇率文件中的一条记录，Date 为空表示一直有效。
+type RateRecord struct {
+	From string          `json:"from"`
+	To   string          `json:"to"`
+	Rate decimal.Decimal `json:"rate"`
+	Date string          `json:"date,omitempty"`
+}
+
+// Load 批量写入汇率记录；先校验全部记录，有非法记录时返回 ErrInvalidRate 且不写入任何记录。
+func (t *RateTable) Load(records ...RateRecord) error {
+	entries := make([]rateEntry, len(records))
+	for i, r := range records {
+		var since types.LocalDate
+		if r.Date != "" {
+			d, err := types.LocalDateParse(r.Date)
+			if err != nil {
+				return fmt.Errorf("%w: %s/%s 的生效日期 %q: %v", ErrInvalidRate, r.From, r.To, r.Date, err)
+			}
+			since = d
+		}
+		e, err := newRateEntry(r.From, r.To, r.Rate, since)
+		if err != nil {
+			return err
+		}
+		entries[i] = e
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range entries {
+		t.put(e)
+	}
+	return nil
+}
+
+// LoadJSON 从 JSON 数组加载汇率，如 [{"from":"USD","to":"CNY","rate":"7.10","date":"2025-01-01"}]。
+func (t *RateTable) LoadJSON(data []byte) error {
+	var records []RateRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+	return t.Load(records...)
+}
+
+// LoadCSV 从带表头的 CSV 加载汇率，列为 from,to,rate 与可选的 date，列顺序不限。
+func (t *RateTable) LoadCSV(r io.Reader) error {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	col := map[string]int{}
+	for i, name := range rows[0] {
+		col[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"from", "to", "rate"} {
+		if _, ok := col[name]; !ok {
+			return fmt.Errorf("汇率 CSV 缺少 %s 列", name)
+		}
+	}
+	records := make([]RateRecord, 0, len(rows)-1)
+	for n, row := range rows[1:] {
+		rate, err := decimal.NewFromString(strings.TrimSpace(row[col["rate"]]))
+		if err != nil {
+			return fmt.Errorf("汇率 CSV 第 %d 行: %w", n+2, err)
+		}
+		rec := RateRecord{From: strings.TrimSpace(row[col["from"]]), To: strings.TrimSpace(row[col["to"]]), Rate: rate}
+		if i, ok := col["date"]; ok {
+			rec.Date = strings.TrimSpace(row[i])
+		}
+		records = append(records, rec)
+	}
+	return t.Load(records...)
+}
+
+// rateDivPrecision 求倒数、套算汇率时的小数位数。
+const rateDivPrecision = 16
+
+// RoundingMode 舍入方式。
+type RoundingMode int
+
+const (
+	RoundingHalfUp   RoundingMode = iota // 四舍五入（远离零）
+	RoundingHalfEven                     // 银行家舍入
+	RoundingDown                         // 向零截断
+	RoundingUp                           // 远离零进位
+	RoundingNone                         // 不舍入
+)
+
+// apply 按舍入方式保留 places 位小数。
+func (m RoundingMode) apply(d decimal.Decimal, places int32) decimal.Decimal {
+	switch m {
+	case RoundingHalfEven:
+		return d.RoundBank(places)
+	case RoundingDown:
+		return d.RoundDown(places)
+	case RoundingUp:
+		return d.RoundUp(places)
+	case RoundingNone:
+		return d
+	}
+	return d.Round(places)
+}
+
+type rounding struct {
+	places int32
+	mode   RoundingMode
+}
+
+type convertOpts struct {
+	base     string
+	mode     RoundingMode
+	rounding map[string]rounding
+}
+
+// ConvertOpts 创建换算选项，默认不套算，结果按目标币种辅币位数四舍五入。
+func ConvertOpts() *convertOpts {
+	return &convertOpts{mode: RoundingHalfUp, rounding: map[string]rounding{}}
+}
+
+// Base 设置套算基准币种：找不到直接汇率时按 from→base→to 换算，如以 USD 套算 CNY/JPY。
+func (o *convertOpts) Base(currency string) *convertOpts {
+	o.base = strings.ToUpper(currency)
+	return o
+}
+
+// Rounding 设置默认舍入方式，保留位数为目标币种的辅币位数。
+func (o *convertOpts) Rounding(mode RoundingMode) *convertOpts {
+	o.mode = mode
+	return o
+}
+
+// RoundingFor 为某一目标币种单独设置保留位数与舍入方式，如 JPY 截断到 0 位。
+func (o *convertOpts) RoundingFor(currency string, places int32, mode RoundingMode) *convertOpts {
+	o.rounding[strings.ToUpper(currency)] = rounding{places: places, mode: mode}
+	return o
+}
+
+func resolveConvertOpts(opts ...*convertOpts) *convertOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return ConvertOpts()
+	}
+	return opts[0]
+}
+
+// ExchangeRate 返回 at 当天 from 到 to 的汇率，按选项经由基准币种套算。
+func ExchangeRate(p RateProvider, from, to string, at types.LocalDate, opts ...*convertOpts) (decimal.Decimal, error) {
+	o := resolveConvertOpts(opts...)
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return decimal.NewFromInt(1), nil
+	}
+	rate, err := p.Rate(from, to, at)
+	if err == nil || !errors.Is(err, ErrRateNotFound) || o.base == "" || o.base == from || o.base == to {
+		return rate, err
+	}
+	r1, err := p.Rate(from, o.base, at)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	r2, err := p.Rate(o.base, to, at)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return r1.Mul(r2).Round(rateDivPrecision), nil
+}
+
+// Convert 将 amount 从 from 币种按 at 当天的汇率换算为 to 币种并舍入，如财务报表折算本位币。
+func Convert(p RateProvider, amount any, from, to string, at types.LocalDate, opts ...*convertOpts) (Money, error) {
+	m, err := NewMoney(amount, from)
+	if err != nil {
+		return Money{}, err
+	}
+	return ConvertMoney(p, m, to, at, opts...)
+}
+
+// ConvertMoney 同 Convert，以 Money 作为输入。
+func ConvertMoney(p RateProvider, m Money, to string, at types.LocalDate, opts ...*convertOpts) (Money, error) {
+	o := resolveConvertOpts(opts...)
+	c, err := currencyOf(to)
+	if err != nil {
+		return Money{}, err
+	}
+	rate, err := ExchangeRate(p, m.Currency, c.Code, at, o)
+	if err != nil {
+		return Money{}, err
+	}
+	r, ok := o.rounding[c.Code]
+	if !ok {
+		r = rounding{places: c.MinorUnits, mode: o.mode}
+	}
+	return Money{Amount: r.mode.apply(m.Amount.Mul(rate), r.places), Currency: c.Code}, nil
+}
@@ -0,0 +1,112 @@
+package moneyutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateTable(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	day := types.LocalDateOfYmd(2025, 3, 1)
+	rates := NewRateTable()
+	req.NoError(rates.Set("USD", "CNY", "7.20"))
+	req.NoError(rates.SetAt("USD", "CNY", "7.10", types.LocalDateOfYmd(2025, 1, 1)))
+	req.NoError(rates.SetAt("USD", "CNY", "7.30", types.LocalDateOfYmd(2025, 6, 1)))
+
+	r, err := rates.Rate("usd", "cny", types.LocalDateOfYmd(2024, 12, 31))
+	as.NoError(err)
+	as.Equal("7.2", r.String())
+	r, _ = rates.Rate("USD", "CNY", day)
+	as.Equal("7.1", r.String())
+	r, _ = rates.Rate("USD", "CNY", types.LocalDateOfYmd(2025, 6, 1))
+	as.Equal("7.3", r.String())
+
+	// 反向汇率取倒数
+	r, err = rates.Rate("CNY", "USD", day)
+	as.NoError(err)
+	as.True(r.Mul(decimal.RequireFromString("7.1")).Round(10).Equal(decimal.NewFromInt(1)))
+
+	_, err = rates.Rate("USD", "JPY", day)
+	as.ErrorIs(err, ErrRateNotFound)
+
+	as.ErrorIs(rates.Set("USD", "JPY", 0), ErrInvalidRate)
+	as.ErrorIs(rates.SetAt("USD", "CNY", "-7.1", day), ErrInvalidRate)
+	as.ErrorIs(rates.Set("", "CNY", 7), ErrInvalidRate)
+	r, _ = rates.Rate("USD", "CNY", day)
+	as.Equal("7.1", r.String())
+	_, err = rates.Rate("USD", "JPY", day)
+	as.ErrorIs(err, ErrRateNotFound)
+}
+
+func TestRateTableLoad(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	rates := NewRateTable()
+	req.NoError(rates.LoadCSV(strings.NewReader("date,from,to,rate\n2025-01-01,EUR,USD,1.04\n,EUR,USD,1.10\n")))
+	req.NoError(rates.LoadJSON([]byte(`[{"from":"USD","to":"JPY","rate":"150.5"},{"from":"USD","to":"JPY","rate":157,"date":"2025-01-01"}]`)))
+
+	r, _ := rates.Rate("EUR", "USD", types.LocalDateOfYmd(2025, 2, 1))
+	as.Equal("1.04", r.String())
+	r, _ = rates.Rate("EUR", "USD", types.LocalDateOfYmd(2024, 2, 1))
+	as.Equal("1.1", r.String())
+	r, _ = rates.Rate("USD", "JPY", types.LocalDateOfYmd(2025, 2, 1))
+	as.Equal("157", r.String())
+
+	as.Error(rates.LoadCSV(strings.NewReader("from,to\nUSD,CNY\n")))
+	as.Error(rates.LoadCSV(strings.NewReader("from,to,rate\nUSD,CNY,abc\n")))
+	as.ErrorIs(rates.LoadJSON([]byte(`[{"from":"USD","to":"CNY","rate":"-1"}]`)), ErrInvalidRate)
+	as.ErrorIs(rates.LoadJSON([]byte(`[{"from":"USD","to":"CNY","rate":"7","date":"2025/13/01"}]`)), ErrInvalidRate)
+
+	// 有非法记录时整批不写入
+	err := rates.LoadJSON([]byte(`[{"from":"EUR","to":"USD","rate":"2","date":"2025-01-01"},{"from":"USD","to":"CNY","rate":"0"}]`))
+	as.ErrorIs(err, ErrInvalidRate)
+	r, _ = rates.Rate("EUR", "USD", types.LocalDateOfYmd(2025, 2, 1))
+	as.Equal("1.04", r.String())
+}
+
+func TestConvert(t *testing.T) {
+	as := assert.New(t)
+	day := types.LocalDateOfYmd(2025, 3, 1)
+	rates := NewRateTable()
+	require.NoError(t, rates.Load(RateRecord{From: "USD", To: "CNY", Rate: decimal.RequireFromString("7.1234")},
+		RateRecord{From: "USD", To: "JPY", Rate: decimal.RequireFromString("149.87")}))
+
+	m, err := Convert(rates, 100, "USD", "CNY", day)
+	as.NoError(err)
+	as.Equal("CNY 712.34", m.String())
+
+	m, err = Convert(rates, "100", "CNY", "USD", day)
+	as.NoError(err)
+	as.Equal("USD 14.04", m.String())
+
+	// CNY→JPY 无直接汇率，经 USD 套算：100 / 7.1234 * 149.87 = 2103.91...
+	_, err = Convert(rates, 100, "CNY", "JPY", day)
+	as.ErrorIs(err, ErrRateNotFound)
+	opts := ConvertOpts().Base("USD")
+	m, err = Convert(rates, 100, "CNY", "JPY", day, opts)
+	as.NoError(err)
+	as.Equal("JPY 2104", m.String())
+	m, err = Convert(rates, 100, "CNY", "JPY", day, opts.RoundingFor("JPY", 0, RoundingDown))
+	as.NoError(err)
+	as.Equal("JPY 2103", m.String())
+
+	m, err = ConvertMoney(rates, MustMoney("0.015", "USD"), "USD", day, ConvertOpts().Rounding(RoundingHalfEven))
+	as.NoError(err)
+	as.Equal("USD 0.02", m.String())
+
+	fixed := RateFunc(func(from, to string, at types.LocalDate) (decimal.Decimal, error) {
+		return decimal.NewFromInt(2), nil
+	})
+	m, err = Convert(fixed, "1.5", "EUR", "USD", day)
+	as.NoError(err)
+	as.Equal("USD 3.00", m.String())
+
+	_, err = Convert(rates, 1, "USD", "XYZ", day)
+	as.ErrorIs(err, ErrUnknownCurrency)
+}
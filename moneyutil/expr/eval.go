@@ -0,0 +1,231 @@
+package expr
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/lontten/lcore/v2/types"
+	"github.com/shopspring/decimal"
+)
+
+// value 求值结果，数值或布尔值。
+type value struct {
+	num    decimal.Decimal
+	b      bool
+	isBool bool
+}
+
+type env struct {
+	vars map[string]any
+	opts *compileOpts
+}
+
+type node interface {
+	eval(e *env) (value, error)
+}
+
+type numberNode struct {
+	v decimal.Decimal
+}
+
+func (n *numberNode) eval(*env) (value, error) {
+	return value{num: n.v}, nil
+}
+
+type boolNode struct {
+	v bool
+}
+
+func (n *boolNode) eval(*env) (value, error) {
+	return value{b: n.v, isBool: true}, nil
+}
+
+type varNode struct {
+	name string
+	pos  int
+}
+
+func (n *varNode) eval(e *env) (value, error) {
+	raw, ok := e.vars[n.name]
+	if !ok {
+		return value{}, newError(ErrUndefined, n.pos, "%s", n.name)
+	}
+	v, ok := toValue(raw)
+	if !ok {
+		return value{}, newError(ErrEval, n.pos, "变量 %s 的类型 %T 不支持", n.name, raw)
+	}
+	return v, nil
+}
+
+// toValue 将变量值转换为 value，支持 decimal、整数、浮点数、数字字符串与 bool。
+func toValue(raw any) (value, bool) {
+	switch v := raw.(type) {
+	case decimal.Decimal:
+		return value{num: v}, true
+	case *decimal.Decimal:
+		if v == nil {
+			return value{}, false
+		}
+		return value{num: *v}, true
+	case bool:
+		return value{b: v, isBool: true}, true
+	case string:
+		d, err := decimal.NewFromString(v)
+		return value{num: d}, err == nil
+	case float32:
+		return value{num: types.ToDecimal(v)}, !math.IsNaN(float64(v)) && !math.IsInf(float64(v), 0)
+	case float64:
+		return value{num: types.ToDecimal(v)}, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return value{num: types.ToDecimal(v)}, true
+	}
+	return value{}, false
+}
+
+// evalNum 求值并要求结果为数值。
+func evalNum(n node, e *env, pos int) (decimal.Decimal, error) {
+	v, err := n.eval(e)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if v.isBool {
+		return decimal.Zero, newError(ErrEval, pos, "需要数值，实际为布尔值 %s", strconv.FormatBool(v.b))
+	}
+	return v.num, nil
+}
+
+// evalBool 求值并要求结果为布尔值。
+func evalBool(n node, e *env, pos int) (bool, error) {
+	v, err := n.eval(e)
+	if err != nil {
+		return false, err
+	}
+	if !v.isBool {
+		return false, newError(ErrEval, pos, "需要布尔值，实际为数值 %s", v.num)
+	}
+	return v.b, nil
+}
+
+type unaryNode struct {
+	op  string
+	x   node
+	pos int
+}
+
+func (n *unaryNode) eval(e *env) (value, error) {
+	if n.op == "!" {
+		b, err := evalBool(n.x, e, n.pos)
+		return value{b: !b, isBool: true}, err
+	}
+	d, err := evalNum(n.x, e, n.pos)
+	if err != nil {
+		return value{}, err
+	}
+	if n.op == "-" {
+		d = d.Neg()
+	}
+	return value{num: d}, nil
+}
+
+type binaryNode struct {
+	op   string
+	l, r node
+	pos  int
+}
+
+func (n *binaryNode) eval(e *env) (value, error) {
+	switch n.op {
+	case "&&", "||":
+		// 短路求值
+		l, err := evalBool(n.l, e, n.pos)
+		if err != nil || l == (n.op == "||") {
+			return value{b: l, isBool: true}, err
+		}
+		r, err := evalBool(n.r, e, n.pos)
+		return value{b: r, isBool: true}, err
+	case "==", "!=":
+		l, err := n.l.eval(e)
+		if err != nil {
+			return value{}, err
+		}
+		r, err := n.r.eval(e)
+		if err != nil {
+			return value{}, err
+		}
+		if l.isBool != r.isBool {
+			return value{}, newError(ErrEval, n.pos, "不能比较数值与布尔值")
+		}
+		eq := l.isBool && l.b == r.b || !l.isBool && l.num.Equal(r.num)
+		return value{b: eq == (n.op == "=="), isBool: true}, nil
+	}
+
+	l, err := evalNum(n.l, e, n.pos)
+	if err != nil {
+		return value{}, err
+	}
+	r, err := evalNum(n.r, e, n.pos)
+	if err != nil {
+		return value{}, err
+	}
+	switch n.op {
+	case "+":
+		return value{num: l.Add(r)}, nil
+	case "-":
+		return value{num: l.Sub(r)}, nil
+	case "*":
+		return value{num: l.Mul(r)}, nil
+	case "/":
+		if r.IsZero() {
+			return value{}, newError(ErrEval, n.pos, "除数为 0")
+		}
+		return value{num: l.DivRound(r, e.opts.divPrecision)}, nil
+	case "<":
+		return value{b: l.LessThan(r), isBool: true}, nil
+	case "<=":
+		return value{b: l.LessThanOrEqual(r), isBool: true}, nil
+	case ">":
+		return value{b: l.GreaterThan(r), isBool: true}, nil
+	default: // ">="
+		return value{b: l.GreaterThanOrEqual(r), isBool: true}, nil
+	}
+}
+
+type ternaryNode struct {
+	cond, a, b node
+	pos        int
+}
+
+// eval 只求值被选中的分支，如 qty > 0 ? total / qty : 0 在 qty 为 0 时不会除以 0。
+func (n *ternaryNode) eval(e *env) (value, error) {
+	c, err := evalBool(n.cond, e, n.pos)
+	if err != nil {
+		return value{}, err
+	}
+	if c {
+		return n.a.eval(e)
+	}
+	return n.b.eval(e)
+}
+
+type callNode struct {
+	name string
+	fn   *function
+	args []node
+	pos  int
+}
+
+func (n *callNode) eval(e *env) (value, error) {
+	args := make([]decimal.Decimal, len(n.args))
+	for i, arg := range n.args {
+		d, err := evalNum(arg, e, n.pos)
+		if err != nil {
+			return value{}, err
+		}
+		args[i] = d
+	}
+	d, msg := n.fn.call(args)
+	if msg != "" {
+		return value{}, newError(ErrEval, n.pos, "%s: %s", n.name, msg)
+	}
+	return value{num: d}, nil
+}
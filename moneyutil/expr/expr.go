@@ -0,0 +1,142 @@
+// Package expr 提供基于 decimal.Decimal 的安全表达式解析与求值，用于运营配置的定价公式，
+// 如 base * (1 + rate) - min(coupon, 50)。公式只能做算术、比较、逻辑与条件运算及调用内置函数，不能访问任何外部状态。
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrSyntax 公式语法错误，如括号不匹配、未知函数或参数个数不对。
+	ErrSyntax = errors.New("表达式语法错误")
+	// ErrUndefined 求值时变量未提供。
+	ErrUndefined = errors.New("未定义的变量")
+	// ErrEval 求值错误，如除以 0、对布尔值做算术运算或变量类型不支持。
+	ErrEval = errors.New("表达式求值错误")
+)
+
+// Error 带位置的表达式错误，可用 errors.Is 判断是 ErrSyntax、ErrUndefined 还是 ErrEval。
+type Error struct {
+	Pos int    // 出错处在公式中的字符位置，从 1 开始
+	Msg string // 具体原因
+	Err error  // ErrSyntax、ErrUndefined 或 ErrEval
+}
+
+func newError(err error, pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...), Err: err}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v（位置 %d）: %s", e.Err, e.Pos, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type compileOpts struct {
+	divPrecision int32
+}
+
+// CompileOpts 创建编译选项，默认除法保留 16 位小数。
+func CompileOpts() *compileOpts {
+	return &compileOpts{divPrecision: 16}
+}
+
+// DivPrecision 设置除法结果保留的小数位数（四舍五入）。
+func (o *compileOpts) DivPrecision(p int32) *compileOpts {
+	o.divPrecision = p
+	return o
+}
+
+func resolveCompileOpts(opts ...*compileOpts) *compileOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return CompileOpts()
+	}
+	return opts[0]
+}
+
+// Program 编译后的公式，可并发地多次求值。
+type Program struct {
+	src  string
+	root node
+	vars []string
+	opts compileOpts
+}
+
+// Compile 编译公式。支持：
+//   - 数值字面量、true/false 与变量（字母、数字、下划线，可用中文）
+//   - 算术 + - * /，比较 == != < <= > >=，逻辑 && || !，条件 cond ? a : b
+//   - 内置函数 round、roundBank、roundDown、roundUp（第二个参数为小数位数）、max、min、sum、average、
+//     percentage、abs，函数名不区分大小写
+//
+// 语法错误在编译时返回，变量缺失与除以 0 等错误在求值时返回，均为带位置的 *Error。
+func Compile(src string, opts ...*compileOpts) (*Program, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(p.vars)
+	return &Program{src: src, root: root, vars: slices.Compact(p.vars), opts: *resolveCompileOpts(opts...)}, nil
+}
+
+// MustCompile 同 Compile，出错时 panic，用于编译期已知的公式。
+func MustCompile(src string, opts ...*compileOpts) *Program {
+	p, err := Compile(src, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String 返回公式原文。
+func (p *Program) String() string {
+	return p.src
+}
+
+// Variables 返回公式引用的变量名，按字典序排列，可用于保存配置时校验变量是否齐全。
+func (p *Program) Variables() []string {
+	return slices.Clone(p.vars)
+}
+
+// Eval 以 vars 中的变量求值，结果须为数值。变量值可以是 decimal.Decimal、整数、浮点数、数字字符串或 bool。
+func (p *Program) Eval(vars map[string]any) (decimal.Decimal, error) {
+	v, err := p.root.eval(&env{vars: vars, opts: &p.opts})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if v.isBool {
+		return decimal.Zero, newError(ErrEval, 1, "结果为布尔值，需要数值")
+	}
+	return v.num, nil
+}
+
+// EvalBool 以 vars 中的变量求值，结果须为布尔值，用于配置化的条件判断。
+func (p *Program) EvalBool(vars map[string]any) (bool, error) {
+	v, err := p.root.eval(&env{vars: vars, opts: &p.opts})
+	if err != nil {
+		return false, err
+	}
+	if !v.isBool {
+		return false, newError(ErrEval, 1, "结果为数值，需要布尔值")
+	}
+	return v.b, nil
+}
+
+// Eval 编译并求值公式，只求值一次时使用；同一公式反复求值时应先 Compile。
+func Eval(src string, vars map[string]any, opts ...*compileOpts) (decimal.Decimal, error) {
+	p, err := Compile(src, opts...)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return p.Eval(vars)
+}
@@ -0,0 +1,114 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEval(t *testing.T) {
+	as := assert.New(t)
+	vars := map[string]any{
+		"base":   decimal.RequireFromString("199.90"),
+		"rate":   "0.13",
+		"coupon": 80,
+		"qty":    uint8(3),
+		"vip":    true,
+		"折扣":     0.85,
+	}
+	cases := []struct {
+		src  string
+		want string
+	}{
+		{"base * (1 + rate) - min(coupon, 50)", "175.887"},
+		{"round(base * (1 + rate) - min(coupon, 50), 2)", "175.89"},
+		{"roundBank(2.345, 2) + ROUNDBANK(2.355, 2)", "4.7"},
+		{"roundDown(base, 0) + roundUp(0.001, 2)", "199.01"},
+		{"1 + 2 * 3 - 4 / 2", "5"},
+		{"-2 * -(3 - 5)", "-4"},
+		{"10 - 2 - 3", "5"},
+		{"vip ? base * 折扣 : base", "169.915"},
+		{"qty >= 3 && !vip ? 1 : qty > 2 || false ? 2 : 3", "2"},
+		{"max(1, qty, .5) + sum(1, 2) + average(2, 4) + abs(-1)", "10"},
+		{"percentage(coupon, 200)", "40"},
+		{"base == 199.9 ? 1 : 0", "1"},
+		{"vip != false ? 1 : 0", "1"},
+		{"qty > 0 ? coupon / qty : 0", "26.6666666666666667"},
+	}
+	for _, c := range cases {
+		got, err := Eval(c.src, vars)
+		require.NoError(t, err, c.src)
+		as.Equal(c.want, got.String(), c.src)
+	}
+
+	got, err := Eval("10 / 3", nil, CompileOpts().DivPrecision(4))
+	as.NoError(err)
+	as.Equal("3.3333", got.String())
+
+	// 未选中的分支不求值
+	got, err = Eval("qty > 0 ? 1 / qty : 0", map[string]any{"qty": 0})
+	as.NoError(err)
+	as.True(got.IsZero())
+}
+
+func TestProgram(t *testing.T) {
+	as := assert.New(t)
+	p := MustCompile("price * qty > threshold && member")
+	as.Equal([]string{"member", "price", "qty", "threshold"}, p.Variables())
+	as.Equal("price * qty > threshold && member", p.String())
+
+	for qty, want := range map[int]bool{1: false, 5: true} {
+		ok, err := p.EvalBool(map[string]any{"price": "25", "qty": qty, "threshold": 100, "member": true})
+		as.NoError(err)
+		as.Equal(want, ok)
+	}
+	_, err := p.Eval(map[string]any{"price": "25", "qty": 5, "threshold": 100, "member": true})
+	as.ErrorIs(err, ErrEval)
+	_, err = MustCompile("1 + 1").EvalBool(nil)
+	as.ErrorIs(err, ErrEval)
+	as.Panics(func() { MustCompile("1 +") })
+}
+
+func TestErrors(t *testing.T) {
+	as := assert.New(t)
+	cases := []struct {
+		src  string
+		vars map[string]any
+		err  error
+		pos  int
+	}{
+		{"1 + ", nil, ErrSyntax, 5},
+		{"(1 + 2", nil, ErrSyntax, 7},
+		{"1 + 2)", nil, ErrSyntax, 6},
+		{"1 # 2", nil, ErrSyntax, 3},
+		{"base * foo(1)", nil, ErrSyntax, 8},
+		{"round(1)", nil, ErrSyntax, 1},
+		{"min()", nil, ErrSyntax, 1},
+		{"a ? 1", nil, ErrSyntax, 6},
+		{"1.2.3", nil, ErrSyntax, 4},
+		{"base * (1 + rate)", map[string]any{"base": 1}, ErrUndefined, 13},
+		{"10 / (a - 1)", map[string]any{"a": 1}, ErrEval, 4},
+		{"1 + true", nil, ErrEval, 3},
+		{"a ? 1 : 2", map[string]any{"a": 1}, ErrEval, 3},
+		{"!a", map[string]any{"a": 1}, ErrEval, 1},
+		{"a == true", map[string]any{"a": 1}, ErrEval, 3},
+		{"x + 1", map[string]any{"x": "abc"}, ErrEval, 1},
+		{"x + 1", map[string]any{"x": []int{1}}, ErrEval, 1},
+		{"round(1, 0.5)", nil, ErrEval, 1},
+		{"percentage(1, a)", map[string]any{"a": 0}, ErrEval, 1},
+	}
+	for _, c := range cases {
+		_, err := Eval(c.src, c.vars)
+		as.ErrorIs(err, c.err, c.src)
+		var e *Error
+		if as.True(errors.As(err, &e), c.src) {
+			as.Equal(c.pos, e.Pos, "%s: %v", c.src, err)
+		}
+	}
+
+	_, err := Eval("单价 * ", map[string]any{"单价": 1})
+	as.EqualError(err, "表达式语法错误（位置 6）: 意外的 公式结尾")
+}
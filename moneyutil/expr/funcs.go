@@ -0,0 +1,67 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lontten/lutil/moneyutil"
+	"github.com/shopspring/decimal"
+)
+
+// function 内置函数。call 出错时返回非空的错误说明，由调用处补充位置。
+type function struct {
+	minArgs, maxArgs int // maxArgs 为 -1 表示不限
+	call             func(args []decimal.Decimal) (decimal.Decimal, string)
+}
+
+func (f *function) arity() string {
+	switch {
+	case f.maxArgs < 0:
+		return fmt.Sprintf("至少 %d 个", f.minArgs)
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d 个", f.minArgs)
+	}
+	return fmt.Sprintf("%d~%d 个", f.minArgs, f.maxArgs)
+}
+
+// builtins 内置函数表，键为小写函数名。
+var builtins = map[string]*function{
+	"round":      roundFunc(moneyutil.Round),
+	"roundbank":  roundFunc(moneyutil.RoundBank),
+	"rounddown":  roundFunc(moneyutil.RoundDown),
+	"roundup":    roundFunc(moneyutil.RoundUp),
+	"max":        variadicFunc(moneyutil.Max),
+	"min":        variadicFunc(moneyutil.Min),
+	"sum":        variadicFunc(moneyutil.Sum),
+	"average":    variadicFunc(moneyutil.Average),
+	"abs":        {minArgs: 1, maxArgs: 1, call: func(args []decimal.Decimal) (decimal.Decimal, string) { return moneyutil.Abs(args[0]), "" }},
+	"percentage": {minArgs: 2, maxArgs: 2, call: percentage},
+}
+
+// roundFunc 包装舍入函数，第二个参数为小数位数，须为整数。
+func roundFunc(round func(d any, precision int32) decimal.Decimal) *function {
+	return &function{minArgs: 2, maxArgs: 2, call: func(args []decimal.Decimal) (decimal.Decimal, string) {
+		p := args[1]
+		if !p.IsInteger() || p.Abs().GreaterThan(decimal.NewFromInt(math.MaxInt16)) {
+			return decimal.Zero, fmt.Sprintf("小数位数 %s 须为整数", p)
+		}
+		return round(args[0], int32(p.IntPart())), ""
+	}}
+}
+
+func variadicFunc(fn func(values ...any) decimal.Decimal) *function {
+	return &function{minArgs: 1, maxArgs: -1, call: func(args []decimal.Decimal) (decimal.Decimal, string) {
+		values := make([]any, len(args))
+		for i, a := range args {
+			values[i] = a
+		}
+		return fn(values...), ""
+	}}
+}
+
+func percentage(args []decimal.Decimal) (decimal.Decimal, string) {
+	if args[1].IsZero() {
+		return decimal.Zero, "总数为 0"
+	}
+	return moneyutil.Percentage(args[0], args[1]), ""
+}
@@ -0,0 +1,76 @@
+package expr
+
+import (
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 从 1 开始的字符位置
+}
+
+// twoCharOps 双字符运算符，需先于单字符匹配。
+var twoCharOps = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+const oneCharOps = "+-*/(),?:<>!"
+
+// lex 将公式切分为记号，遇到无法识别的字符时返回带位置的 ErrSyntax。
+func lex(src string) ([]token, error) {
+	rs := []rune(src)
+	var toks []token
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1]):
+			start := i
+			dot := false
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.' && !dot) {
+				dot = dot || rs[i] == '.'
+				i++
+			}
+			toks = append(toks, token{kind: tokNumber, text: string(rs[start:i]), pos: start + 1})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			toks = append(toks, token{kind: tokIdent, text: string(rs[start:i]), pos: start + 1})
+		default:
+			op := ""
+			if i+1 < len(rs) {
+				for _, two := range twoCharOps {
+					if string(rs[i:i+2]) == two {
+						op = two
+						break
+					}
+				}
+			}
+			if op == "" {
+				for _, c := range oneCharOps {
+					if r == c {
+						op = string(r)
+						break
+					}
+				}
+			}
+			if op == "" {
+				return nil, newError(ErrSyntax, i+1, "无法识别的字符 %q", r)
+			}
+			toks = append(toks, token{kind: tokOp, text: op, pos: i + 1})
+			i += len([]rune(op))
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(rs) + 1}), nil
+}
@@ -0,0 +1,215 @@
+package expr
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// parser 递归下降解析器，优先级从低到高：?:、||、&&、比较、+ -、* /、一元运算。
+type parser struct {
+	toks []token
+	i    int
+	vars []string
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept 下一个记号是运算符 op 时消费并返回 true。
+func (p *parser) accept(op string) (token, bool) {
+	t := p.peek()
+	if t.kind == tokOp && t.text == op {
+		p.i++
+		return t, true
+	}
+	return t, false
+}
+
+func (p *parser) expect(op string) error {
+	if t, ok := p.accept(op); !ok {
+		return newError(ErrSyntax, t.pos, "期望 %q，实际为 %s", op, describe(t))
+	}
+	return nil
+}
+
+func describe(t token) string {
+	if t.kind == tokEOF {
+		return "公式结尾"
+	}
+	return "\"" + t.text + "\""
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, newError(ErrSyntax, t.pos, "多余的 %s", describe(t))
+	}
+	return n, nil
+}
+
+func (p *parser) ternary() (node, error) {
+	cond, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	q, ok := p.accept("?")
+	if !ok {
+		return cond, nil
+	}
+	a, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	b, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return &ternaryNode{cond: cond, a: a, b: b, pos: q.pos}, nil
+}
+
+func (p *parser) or() (node, error) {
+	return p.binaryLevel(p.and, "||")
+}
+
+func (p *parser) and() (node, error) {
+	return p.binaryLevel(p.compare, "&&")
+}
+
+func (p *parser) compare() (node, error) {
+	l, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if t, ok := p.accept(op); ok {
+			r, err := p.additive()
+			if err != nil {
+				return nil, err
+			}
+			return &binaryNode{op: op, l: l, r: r, pos: t.pos}, nil
+		}
+	}
+	return l, nil
+}
+
+func (p *parser) additive() (node, error) {
+	return p.binaryLevel(p.multiplicative, "+", "-")
+}
+
+func (p *parser) multiplicative() (node, error) {
+	return p.binaryLevel(p.unary, "*", "/")
+}
+
+// binaryLevel 解析左结合的同级二元运算。
+func (p *parser) binaryLevel(operand func() (node, error), ops ...string) (node, error) {
+	l, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokOp || !slices.Contains(ops, t.text) {
+			return l, nil
+		}
+		p.next()
+		r, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		l = &binaryNode{op: t.text, l: l, r: r, pos: t.pos}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	t := p.peek()
+	if t.kind == tokOp && (t.text == "-" || t.text == "+" || t.text == "!") {
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: t.text, x: x, pos: t.pos}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		d, err := decimal.NewFromString(t.text)
+		if err != nil {
+			return nil, newError(ErrSyntax, t.pos, "非法的数值 %q", t.text)
+		}
+		return &numberNode{v: d}, nil
+	case tokIdent:
+		if _, ok := p.accept("("); ok {
+			return p.call(t)
+		}
+		switch t.text {
+		case "true":
+			return &boolNode{v: true}, nil
+		case "false":
+			return &boolNode{v: false}, nil
+		}
+		p.vars = append(p.vars, t.text)
+		return &varNode{name: t.text, pos: t.pos}, nil
+	case tokOp:
+		if t.text == "(" {
+			n, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+	return nil, newError(ErrSyntax, t.pos, "意外的 %s", describe(t))
+}
+
+// call 解析函数调用，左括号已消费；函数名与参数个数在编译时检查。
+func (p *parser) call(name token) (node, error) {
+	fn, ok := builtins[strings.ToLower(name.text)]
+	if !ok {
+		return nil, newError(ErrSyntax, name.pos, "未知的函数 %s", name.text)
+	}
+	var args []node
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+	if len(args) < fn.minArgs || fn.maxArgs >= 0 && len(args) > fn.maxArgs {
+		return nil, newError(ErrSyntax, name.pos, "函数 %s 的参数个数 %d 不符合要求（%s）", name.text, len(args), fn.arity())
+	}
+	return &callNode{name: name.text, fn: fn, args: args, pos: name.pos}, nil
+}
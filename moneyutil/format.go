@@ -0,0 +1,143 @@
+package moneyutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrUnknownLocale 不支持的地区。
+var ErrUnknownLocale = errors.New("不支持的地区")
+
+// Locale 金额格式化的地区，如 zh-CN、en-US。
+type Locale string
+
+const (
+	LocaleZhCN Locale = "zh-CN"
+	LocaleEnUS Locale = "en-US"
+)
+
+// localeFormat 地区的数字与货币符号习惯（CLDR）。
+type localeFormat struct {
+	group   string
+	decimal string
+	symbols map[string]string // 与币种表不同的符号，用于区分同符号币种
+}
+
+var localeFormats = map[Locale]localeFormat{
+	LocaleZhCN: {group: ",", decimal: ".", symbols: map[string]string{"USD": "US$", "JPY": "JP¥", "HKD": "HK$", "TWD": "NT$"}},
+	LocaleEnUS: {group: ",", decimal: ".", symbols: map[string]string{"CNY": "CN¥"}},
+}
+
+type formatOpts struct {
+	places     int32
+	hasPlaces  bool
+	accounting bool
+	symbol     bool
+	code       bool
+}
+
+// FormatOpts 创建格式化选项，默认显示货币符号，保留币种的辅币位数，负数前缀 -。
+func FormatOpts() *formatOpts {
+	return &formatOpts{symbol: true}
+}
+
+// Places 设置保留的小数位数，替代币种的辅币位数。
+func (o *formatOpts) Places(n int32) *formatOpts {
+	o.places, o.hasPlaces = n, true
+	return o
+}
+
+// Accounting 设置会计格式：负数以括号表示，如 (¥1,234.00)。
+func (o *formatOpts) Accounting(b bool) *formatOpts {
+	o.accounting = b
+	return o
+}
+
+// Symbol 设置是否显示货币符号，不显示时仅输出分组后的数字。
+func (o *formatOpts) Symbol(b bool) *formatOpts {
+	o.symbol = b
+	return o
+}
+
+// Code 设置以币种代码代替符号，如 CNY 1,234.00。
+func (o *formatOpts) Code(b bool) *formatOpts {
+	o.code = b
+	return o
+}
+
+func resolveFormatOpts(opts ...*formatOpts) *formatOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return FormatOpts()
+	}
+	return opts[0]
+}
+
+// Format 按地区格式化金额，如 zh-CN 下 12345.67 CNY 为 ¥12,345.67，en-US 下为 CN¥12,345.67。
+// 金额四舍五入到币种的辅币位数；符号为字母时与数字间加空格，如 CHF 12.00。
+func Format(amount any, locale Locale, currency string, opts ...*formatOpts) (string, error) {
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		return "", err
+	}
+	return m.Format(locale, opts...)
+}
+
+// Format 按地区格式化金额，规则同 Format 函数。
+func (m Money) Format(locale Locale, opts ...*formatOpts) (string, error) {
+	o := resolveFormatOpts(opts...)
+	lf, ok := localeFormats[locale]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownLocale, locale)
+	}
+	c, err := currencyOf(m.Currency)
+	if err != nil {
+		return "", err
+	}
+	places := c.MinorUnits
+	if o.hasPlaces {
+		places = o.places
+	}
+	d := m.Amount.Round(places)
+	neg := d.IsNegative()
+	num := groupDigits(d.Abs().StringFixed(max(places, 0)), lf.group, lf.decimal)
+
+	if o.symbol {
+		symbol := c.Symbol
+		if s, ok := lf.symbols[c.Code]; ok {
+			symbol = s
+		}
+		if o.code || symbol == "" {
+			symbol = c.Code
+		}
+		if r := []rune(symbol); unicode.IsLetter(r[len(r)-1]) {
+			symbol += " "
+		}
+		num = symbol + num
+	}
+	switch {
+	case neg && o.accounting:
+		return "(" + num + ")", nil
+	case neg:
+		return "-" + num, nil
+	}
+	return num, nil
+}
+
+// groupDigits 为 "1234567.89" 形式的数字加千位分隔符并替换小数点。
+func groupDigits(s, group, point string) string {
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(r)
+	}
+	if hasFrac {
+		b.WriteString(point)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
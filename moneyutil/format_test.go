@@ -0,0 +1,89 @@
+package moneyutil
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToChineseUpper(t *testing.T) {
+	as := assert.New(t)
+	cases := []struct {
+		amount string
+		want   string
+	}{
+		{"12345.67", "壹万贰仟叁佰肆拾伍元陆角柒分"},
+		{"0", "零元整"},
+		{"0.5", "伍角"},
+		{"0.05", "伍分"},
+		{"1.05", "壹元零伍分"},
+		{"10", "壹拾元整"},
+		{"1010.1", "壹仟零壹拾元壹角"},
+		{"1680.32", "壹仟陆佰捌拾元叁角贰分"},
+		{"10001000", "壹仟万零壹仟元整"},
+		{"100010000", "壹亿零壹万元整"},
+		{"100000000.01", "壹亿元零壹分"},
+		{"1200000000000", "壹万贰仟亿元整"},
+		{"-325.045", "负叁佰贰拾伍元零伍分"},
+	}
+	for _, c := range cases {
+		got, err := ToChineseUpper(c.amount)
+		as.NoError(err)
+		as.Equal(c.want, got, c.amount)
+
+		back, err := ParseChineseUpper(got)
+		as.NoError(err, got)
+		as.True(decimal.RequireFromString(c.amount).Round(2).Equal(back), "%s -> %s", got, back)
+	}
+	_, err := ToChineseUpper("10000000000000000")
+	as.ErrorIs(err, ErrInvalidChineseAmount)
+}
+
+func TestParseChineseUpper(t *testing.T) {
+	as := assert.New(t)
+	for s, want := range map[string]string{
+		"拾元整":      "10",
+		"壹佰圆正":     "100",
+		"壹仟元叁角":    "1000.3",
+		"伍万零叁元零陆分": "50003.06",
+	} {
+		got, err := ParseChineseUpper(s)
+		as.NoError(err, s)
+		as.True(decimal.RequireFromString(want).Equal(got), "%s -> %s", s, got)
+	}
+	for _, s := range []string{"", "整", "壹贰元", "壹元伍", "佰元", "壹元ABC", "伍",
+		"元整", "壹拾壹拾元", "伍佰贰仟元", "壹万万元", "贰亿亿元", "壹亿万元", "贰零伍元"} {
+		_, err := ParseChineseUpper(s)
+		as.ErrorIs(err, ErrInvalidChineseAmount, s)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	as := assert.New(t)
+	format := func(amount any, locale Locale, currency string, opts ...*formatOpts) string {
+		s, err := Format(amount, locale, currency, opts...)
+		as.NoError(err)
+		return s
+	}
+	as.Equal("¥12,345.67", format("12345.67", LocaleZhCN, "CNY"))
+	as.Equal("CN¥12,345.67", format("12345.67", LocaleEnUS, "CNY"))
+	as.Equal("$1,234,567.00", format(1234567, LocaleEnUS, "USD"))
+	as.Equal("US$0.50", format("0.5", LocaleZhCN, "USD"))
+	as.Equal("JP¥1,235", format("1234.5", LocaleZhCN, "JPY"))
+	as.Equal("-$1,000.00", format(-1000, LocaleEnUS, "USD"))
+	as.Equal("($1,000.00)", format(-1000, LocaleEnUS, "USD", FormatOpts().Accounting(true)))
+	as.Equal("CHF 99.90", format("99.9", LocaleEnUS, "CHF"))
+	as.Equal("CNY 100.00", format(100, LocaleZhCN, "CNY", FormatOpts().Code(true)))
+	as.Equal("1,234.5", format("1234.49", LocaleZhCN, "CNY", FormatOpts().Symbol(false).Places(1)))
+	as.Equal("KWD 1.000", format(1, LocaleEnUS, "KWD", FormatOpts().Code(true)))
+
+	s, err := MustMoney("-0.01", "EUR").Format(LocaleZhCN, FormatOpts().Accounting(true))
+	as.NoError(err)
+	as.Equal("(€0.01)", s)
+
+	_, err = Format(1, "fr-FR", "EUR")
+	as.ErrorIs(err, ErrUnknownLocale)
+	_, err = Format(1, LocaleZhCN, "XYZ")
+	as.ErrorIs(err, ErrUnknownCurrency)
+}
@@ -0,0 +1,255 @@
+package moneyutil
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrCurrencyMismatch 币种不一致的金额之间进行运算或比较。
+var ErrCurrencyMismatch = errors.New("币种不一致")
+
+// Money 带币种的金额，Currency 为 ISO 4217 字母代码（大写），如 CNY。
+// 加减、比较要求币种一致，舍入默认取币种的辅币位数。
+type Money struct {
+	Amount   decimal.Decimal
+	Currency string
+}
+
+// NewMoney 创建金额，amount 支持整数、浮点数、字符串与 decimal.Decimal，币种不区分大小写。
+func NewMoney(amount any, currency string) (Money, error) {
+	c, err := currencyOf(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: toDecimal(amount), Currency: c.Code}, nil
+}
+
+// MustMoney 同 NewMoney，币种未知时 panic。
+func MustMoney(amount any, currency string) Money {
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ParseMoney 解析 "CNY 12.50" 或 "12.50 CNY" 形式的金额。
+func ParseMoney(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Money{}, fmt.Errorf("非法金额 %q", s)
+	}
+	code, amount := fields[0], fields[1]
+	if _, err := decimal.NewFromString(code); err == nil {
+		code, amount = amount, code
+	}
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("非法金额 %q: %w", s, err)
+	}
+	return NewMoney(d, code)
+}
+
+// CurrencyInfo 返回币种信息，未知币种返回 false。
+func (m Money) CurrencyInfo() (Currency, bool) {
+	return LookupCurrency(m.Currency)
+}
+
+// MinorUnits 返回币种的辅币位数，未知币种按 2 位。
+func (m Money) MinorUnits() int32 {
+	if c, ok := m.CurrencyInfo(); ok {
+		return c.MinorUnits
+	}
+	return 2
+}
+
+func (m Money) check(o Money) error {
+	if !strings.EqualFold(m.Currency, o.Currency) {
+		return fmt.Errorf("%w: %s 与 %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+// Add 加法，币种不一致时返回 ErrCurrencyMismatch。
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.Currency}, nil
+}
+
+// Sub 减法，币种不一致时返回 ErrCurrencyMismatch。
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Sub(o.Amount), Currency: m.Currency}, nil
+}
+
+// Mul 乘以系数，结果不舍入，需要时再调用 Round。
+func (m Money) Mul(factor any) Money {
+	return Money{Amount: m.Amount.Mul(toDecimal(factor)), Currency: m.Currency}
+}
+
+// Div 除以除数并四舍五入到辅币位数，除数为 0 时 panic。
+func (m Money) Div(divisor any) Money {
+	return Money{Amount: m.Amount.DivRound(toDecimal(divisor), m.MinorUnits()), Currency: m.Currency}
+}
+
+// Round 四舍五入到辅币位数，如 JPY 到元、CNY 到分。
+func (m Money) Round() Money {
+	return Money{Amount: m.Amount.Round(m.MinorUnits()), Currency: m.Currency}
+}
+
+// RoundBank 按银行家舍入法舍入到辅币位数。
+func (m Money) RoundBank() Money {
+	return Money{Amount: m.Amount.RoundBank(m.MinorUnits()), Currency: m.Currency}
+}
+
+// Neg 取负。
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// Abs 取绝对值。
+func (m Money) Abs() Money {
+	return Money{Amount: m.Amount.Abs(), Currency: m.Currency}
+}
+
+// IsZero 判断金额是否为 0。
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// IsNegative 判断金额是否为负。
+func (m Money) IsNegative() bool {
+	return m.Amount.IsNegative()
+}
+
+// IsPositive 判断金额是否为正。
+func (m Money) IsPositive() bool {
+	return m.Amount.IsPositive()
+}
+
+// Cmp 比较大小，返回 -1、0、1，币种不一致时返回 ErrCurrencyMismatch。
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.check(o); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// Equal 判断币种与金额是否都相等，12.5 与 12.50 视为相等。
+func (m Money) Equal(o Money) bool {
+	return m.check(o) == nil && m.Amount.Equal(o.Amount)
+}
+
+// Split 平均分成 n 份，零头从第一份起每份一个辅币单位；金额的小数位不能超过辅币位数。
+func (m Money) Split(n int) ([]Money, error) {
+	parts, err := SplitEvenly(m.Amount, n, m.MinorUnits())
+	return m.wrap(parts), err
+}
+
+// AllocateMoney 按权重分摊金额，精度为币种的辅币位数，规则同 Allocate。
+func AllocateMoney[W any](m Money, weights []W, strategy AllocStrategy) ([]Money, error) {
+	parts, err := Allocate(m.Amount, weights, m.MinorUnits(), strategy)
+	return m.wrap(parts), err
+}
+
+func (m Money) wrap(parts []decimal.Decimal) []Money {
+	if parts == nil {
+		return nil
+	}
+	out := make([]Money, len(parts))
+	for i, p := range parts {
+		out[i] = Money{Amount: p, Currency: m.Currency}
+	}
+	return out
+}
+
+// SumMoney 以 currency 币种求和，列表为空时返回该币种的 0；
+// 币种未知时返回 ErrUnknownCurrency，与 currency 不一致时返回 ErrCurrencyMismatch。
+func SumMoney(currency string, list ...Money) (Money, error) {
+	sum, err := NewMoney(decimal.Zero, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	for _, m := range list {
+		if sum, err = sum.Add(m); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
+
+// amountString 返回金额文本，小数位不足辅币位数时补 0，超出时原样保留。
+func (m Money) amountString() string {
+	minor := m.MinorUnits()
+	if m.Amount.Exponent() >= -minor {
+		return m.Amount.StringFixed(minor)
+	}
+	return m.Amount.String()
+}
+
+// String 返回 "CNY 12.50" 形式。
+func (m Money) String() string {
+	return m.Currency + " " + m.amountString()
+}
+
+type moneyJSON struct {
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON 编码为 {"amount":"12.50","currency":"CNY"}，金额以字符串保留精度。
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.amountString(), m.Currency})
+}
+
+// UnmarshalJSON 解码 {"amount":"12.50","currency":"CNY"}，金额可为字符串或数字，币种需已知。
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := NewMoney(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value 以 "CNY 12.50" 形式写入数据库。
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan 从 "CNY 12.50" 形式的字符串读取；nil 时不修改接收方。
+func (m *Money) Scan(data any) error {
+	var s string
+	switch v := data.(type) {
+	case nil:
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("无法将 %T 扫描为 Money", data)
+	}
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
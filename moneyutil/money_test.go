@@ -0,0 +1,136 @@
+package moneyutil
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ sql.Scanner   = (*Money)(nil)
+	_ driver.Valuer = Money{}
+)
+
+func TestCurrency(t *testing.T) {
+	as := assert.New(t)
+	c, ok := LookupCurrency("cny")
+	as.True(ok)
+	as.Equal(Currency{Code: "CNY", Numeric: "156", MinorUnits: 2, Symbol: "¥", Name: "人民币"}, c)
+	c, _ = LookupCurrency("JPY")
+	as.EqualValues(0, c.MinorUnits)
+	c, _ = LookupCurrency("KWD")
+	as.EqualValues(3, c.MinorUnits)
+	_, ok = LookupCurrency("XYZ")
+	as.False(ok)
+	for _, code := range []string{"BOB", "CRC", "DOP", "HNL", "JMD", "TTD", "VES", "ZMW"} {
+		_, ok = LookupCurrency(code)
+		as.True(ok, code)
+	}
+
+	RegisterCurrency(Currency{Code: "pts", MinorUnits: 0, Name: "积分"})
+	m, err := NewMoney(100, "PTS")
+	as.NoError(err)
+	as.Equal("PTS 100", m.String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	as := assert.New(t)
+	a := MustMoney("12.5", "cny")
+	b := MustMoney(3, "CNY")
+	as.Equal("CNY", a.Currency)
+
+	sum, err := a.Add(b)
+	as.NoError(err)
+	as.Equal("CNY 15.50", sum.String())
+	diff, err := b.Sub(a)
+	as.NoError(err)
+	as.True(diff.IsNegative())
+	as.Equal("CNY -9.50", diff.String())
+
+	_, err = a.Add(MustMoney(1, "USD"))
+	as.ErrorIs(err, ErrCurrencyMismatch)
+	_, err = a.Cmp(MustMoney(1, "USD"))
+	as.ErrorIs(err, ErrCurrencyMismatch)
+	as.False(a.Equal(MustMoney("12.5", "USD")))
+	as.True(a.Equal(MustMoney("12.50", "CNY")))
+	cmp, err := a.Cmp(b)
+	as.NoError(err)
+	as.Equal(1, cmp)
+
+	_, err = NewMoney(1, "XYZ")
+	as.ErrorIs(err, ErrUnknownCurrency)
+
+	// 舍入默认取辅币位数
+	as.Equal("JPY 1235", MustMoney("1234.5", "JPY").Round().String())
+	as.Equal("CNY 1.22", MustMoney("1.225", "CNY").RoundBank().String())
+	as.Equal("KWD 0.333", MustMoney(1, "KWD").Div(3).String())
+	as.Equal("3.702", MustMoney("1.234", "CNY").Mul(3).Amount.String())
+	as.Equal("CNY 1.234", MustMoney("1.234", "CNY").String())
+
+	total, err := SumMoney("CNY", a, b, b)
+	as.NoError(err)
+	as.Equal("CNY 18.50", total.String())
+	_, err = SumMoney("CNY", a, MustMoney(1, "EUR"))
+	as.ErrorIs(err, ErrCurrencyMismatch)
+	_, err = SumMoney("EUR", a)
+	as.ErrorIs(err, ErrCurrencyMismatch)
+	_, err = SumMoney("XYZ")
+	as.ErrorIs(err, ErrUnknownCurrency)
+
+	// 空列表返回该币种的 0，可正常编码往返
+	zero, err := SumMoney("usd")
+	as.NoError(err)
+	as.Equal("USD 0.00", zero.String())
+	data, err := json.Marshal(zero)
+	as.NoError(err)
+	var back Money
+	as.NoError(json.Unmarshal(data, &back))
+	as.Equal(zero.String(), back.String())
+}
+
+func TestMoneySplit(t *testing.T) {
+	as := assert.New(t)
+	parts, err := MustMoney(100, "CNY").Split(3)
+	as.NoError(err)
+	as.Equal([]string{"CNY 33.34", "CNY 33.33", "CNY 33.33"}, []string{parts[0].String(), parts[1].String(), parts[2].String()})
+
+	parts, err = AllocateMoney(MustMoney(1000, "JPY"), []int{1, 1, 1}, AllocLargestRemainder)
+	as.NoError(err)
+	as.Equal("JPY 334", parts[0].String())
+
+	_, err = MustMoney("0.5", "JPY").Split(2)
+	as.ErrorIs(err, ErrInvalidAllocation)
+}
+
+func TestMoneyEncoding(t *testing.T) {
+	as := assert.New(t)
+	req := require.New(t)
+	m := MustMoney("12.5", "CNY")
+
+	raw, err := json.Marshal(m)
+	req.NoError(err)
+	as.JSONEq(`{"amount":"12.50","currency":"CNY"}`, string(raw))
+
+	var got Money
+	req.NoError(json.Unmarshal([]byte(`{"amount":12.5,"currency":"cny"}`), &got))
+	as.True(m.Equal(got))
+	as.Error(json.Unmarshal([]byte(`{"amount":"1","currency":"XYZ"}`), &got))
+
+	v, err := m.Value()
+	req.NoError(err)
+	as.Equal("CNY 12.50", v)
+
+	var scanned Money
+	req.NoError(scanned.Scan([]byte("12.50 CNY")))
+	as.True(m.Equal(scanned))
+	req.NoError(scanned.Scan(nil))
+	as.True(m.Equal(scanned))
+	as.Error(scanned.Scan(12))
+	as.Error(scanned.Scan("CNY"))
+	as.True(decimal.NewFromFloat(12.5).Equal(scanned.Amount))
+}
@@ -12,11 +12,22 @@ func toDecimals(values ...any) []decimal.Decimal {
 	}
 	result := make([]decimal.Decimal, len(values))
 	for i, v := range values {
-		result[i] = types.ToDecimal(v)
+		result[i] = toDecimal(v)
 	}
 	return result
 }
 
+// toDecimal 同 types.ToDecimal，另支持 decimal.Decimal 与 *decimal.Decimal
+func toDecimal(v any) decimal.Decimal {
+	switch d := v.(type) {
+	case decimal.Decimal:
+		return d
+	case *decimal.Decimal:
+		return *d
+	}
+	return types.ToDecimal(v)
+}
+
 // Add 加法运算，支持多个参数连续相加
 func Add(values ...any) decimal.Decimal {
 	return decimalutil.Add(toDecimals(values...)...)
@@ -25,7 +36,7 @@ func Add(values ...any) decimal.Decimal {
 // Sub 减法运算
 // 第一个参数减去后面所有参数
 func Sub(first any, others ...any) decimal.Decimal {
-	return decimalutil.Sub(types.ToDecimal(first), toDecimals(others...)...)
+	return decimalutil.Sub(toDecimal(first), toDecimals(others...)...)
 }
 
 // Mul 乘法运算，支持多个参数连续相乘
@@ -37,28 +48,28 @@ func Mul(values ...any) decimal.Decimal {
 // 第一个参数除以后面所有参数
 // 如果除数为0会panic
 func Div(first any, others ...any) decimal.Decimal {
-	return decimalutil.Div(types.ToDecimal(first), toDecimals(others...)...)
+	return decimalutil.Div(toDecimal(first), toDecimals(others...)...)
 }
 
 // DivRound 带精度的除法运算（四舍五入）
 // 第一个参数除以后面所有参数，并按照指定精度四舍五入
 func DivRound(first any, precision int32, others ...any) decimal.Decimal {
-	return decimalutil.DivRound(types.ToDecimal(first), precision, toDecimals(others...)...)
+	return decimalutil.DivRound(toDecimal(first), precision, toDecimals(others...)...)
 }
 
 // SafeDiv 安全的除法运算，如果除数为0返回默认值而不是panic
 func SafeDiv(first any, defaultValue any, others ...any) decimal.Decimal {
-	return decimalutil.SafeDiv(types.ToDecimal(first), types.ToDecimal(defaultValue), toDecimals(others...)...)
+	return decimalutil.SafeDiv(toDecimal(first), toDecimal(defaultValue), toDecimals(others...)...)
 }
 
 // Abs 绝对值
 func Abs(d any) decimal.Decimal {
-	return decimalutil.Abs(types.ToDecimal(d))
+	return decimalutil.Abs(toDecimal(d))
 }
 
 // Neg 取负数
 func Neg(d any) decimal.Decimal {
-	return decimalutil.Neg(types.ToDecimal(d))
+	return decimalutil.Neg(toDecimal(d))
 }
 
 // Compare 比较两个decimal的大小
@@ -66,32 +77,32 @@ func Neg(d any) decimal.Decimal {
 // 返回 0 如果 d1 == d2
 // 返回 1 如果 d1 > d2
 func Compare(d1, d2 any) int {
-	return decimalutil.Compare(types.ToDecimal(d1), types.ToDecimal(d2))
+	return decimalutil.Compare(toDecimal(d1), toDecimal(d2))
 }
 
 // Equal 判断两个decimal是否相等
 func Equal(d1, d2 any) bool {
-	return decimalutil.Equal(types.ToDecimal(d1), types.ToDecimal(d2))
+	return decimalutil.Equal(toDecimal(d1), toDecimal(d2))
 }
 
 // GreaterThan 判断 d1 是否大于 d2
 func GreaterThan(d1, d2 any) bool {
-	return decimalutil.GreaterThan(types.ToDecimal(d1), types.ToDecimal(d2))
+	return decimalutil.GreaterThan(toDecimal(d1), toDecimal(d2))
 }
 
 // GreaterThanOrEqual 判断 d1 是否大于等于 d2
 func GreaterThanOrEqual(d1, d2 any) bool {
-	return decimalutil.GreaterThanOrEqual(types.ToDecimal(d1), types.ToDecimal(d2))
+	return decimalutil.GreaterThanOrEqual(toDecimal(d1), toDecimal(d2))
 }
 
 // LessThan 判断 d1 是否小于 d2
 func LessThan(d1, d2 any) bool {
-	return decimalutil.LessThan(types.ToDecimal(d1), types.ToDecimal(d2))
+	return decimalutil.LessThan(toDecimal(d1), toDecimal(d2))
 }
 
 // LessThanOrEqual 判断 d1 是否小于等于 d2
 func LessThanOrEqual(d1, d2 any) bool {
-	return decimalutil.LessThanOrEqual(types.ToDecimal(d1), types.ToDecimal(d2))
+	return decimalutil.LessThanOrEqual(toDecimal(d1), toDecimal(d2))
 }
 
 // Sum 计算多个decimal的和
@@ -116,30 +127,30 @@ func Min(values ...any) decimal.Decimal {
 
 // Percentage 计算百分比 (value / total) * 100
 func Percentage(value, total any) decimal.Decimal {
-	return decimalutil.Percentage(types.ToDecimal(value), types.ToDecimal(total))
+	return decimalutil.Percentage(toDecimal(value), toDecimal(total))
 }
 
 // Round 四舍五入到指定精度
 func Round(d any, precision int32) decimal.Decimal {
-	return decimalutil.Round(types.ToDecimal(d), precision)
+	return decimalutil.Round(toDecimal(d), precision)
 }
 
 // RoundBank 银行家舍入法（四舍六入五成双）
 func RoundBank(d any, precision int32) decimal.Decimal {
-	return decimalutil.RoundBank(types.ToDecimal(d), precision)
+	return decimalutil.RoundBank(toDecimal(d), precision)
 }
 
 // RoundDown 向下舍入
 func RoundDown(d any, precision int32) decimal.Decimal {
-	return decimalutil.RoundDown(types.ToDecimal(d), precision)
+	return decimalutil.RoundDown(toDecimal(d), precision)
 }
 
 // RoundUp 向上舍入
 func RoundUp(d any, precision int32) decimal.Decimal {
-	return decimalutil.RoundUp(types.ToDecimal(d), precision)
+	return decimalutil.RoundUp(toDecimal(d), precision)
 }
 
 // RoundCash 现金舍入法（四舍五入到最接近的5的倍数）
 func RoundCash(d any, interval uint8) decimal.Decimal {
-	return decimalutil.RoundCash(types.ToDecimal(d), interval)
+	return decimalutil.RoundCash(toDecimal(d), interval)
 }
@@ -0,0 +1,459 @@
+package moneyutil
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrTooManyPromotions 规则过多，最优组合搜索的组合数超过上限。
+var ErrTooManyPromotions = errors.New("优惠规则组合过多")
+
+// CartLine 购物车中的一行商品。
+type CartLine struct {
+	SKU      string
+	Category string
+	Price    decimal.Decimal // 单价
+	Quantity int
+}
+
+// Amount 返回该行原价金额。
+func (l CartLine) Amount() decimal.Decimal {
+	return l.Price.Mul(decimal.NewFromInt(int64(l.Quantity)))
+}
+
+// Cart 参与优惠计算的购物车。
+type Cart struct {
+	Lines  []CartLine
+	Member bool // 是否会员，决定会员价是否生效
+}
+
+// PromotionScope 优惠适用的商品范围，SKUs 与 Categories 均为空时适用全部商品。
+type PromotionScope struct {
+	SKUs       []string
+	Categories []string
+}
+
+// Match 判断商品行是否在范围内。
+func (s PromotionScope) Match(l CartLine) bool {
+	if len(s.SKUs) == 0 && len(s.Categories) == 0 {
+		return true
+	}
+	return slices.Contains(s.SKUs, l.SKU) || slices.Contains(s.Categories, l.Category)
+}
+
+// PromotionMeta 优惠规则的公共属性。
+type PromotionMeta struct {
+	ID        string
+	Name      string // 展示名称，为空时说明中使用规则自动生成的描述
+	Group     string // 互斥组，同组规则最多使用一个；为空表示不属于任何组
+	Exclusive bool   // 独占，使用时不能与其他任何规则同享
+	Priority  int    // 计算顺序，小的先算，如会员价、折扣先于满减
+	Scope     PromotionScope
+}
+
+// Meta 返回规则的公共属性，嵌入 PromotionMeta 的规则自动实现该方法。
+func (m PromotionMeta) Meta() PromotionMeta {
+	return m
+}
+
+func (m PromotionMeta) label(def string) string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return def
+}
+
+// PromotionRule 优惠规则，可自行实现以扩展新的规则类型。
+type PromotionRule interface {
+	Meta() PromotionMeta
+	// Apply 基于各行当前应付金额计算优惠，返回与 Cart.Lines 等长的各行优惠额与面向用户的说明，
+	// 不满足使用条件时返回 false。
+	Apply(c *PromotionContext) (savings []decimal.Decimal, explain string, ok bool)
+}
+
+// PromotionContext 规则计算时的上下文。
+type PromotionContext struct {
+	Cart      Cart
+	Amounts   []decimal.Decimal // 各行当前应付金额，即原价减去先前规则的优惠
+	Precision int32             // 金额精度
+}
+
+// Eligible 返回在范围内且当前应付金额为正的行下标及其应付合计。
+func (c *PromotionContext) Eligible(scope PromotionScope) ([]int, decimal.Decimal) {
+	var idx []int
+	base := decimal.Zero
+	for i, l := range c.Cart.Lines {
+		if scope.Match(l) && c.Amounts[i].IsPositive() {
+			idx = append(idx, i)
+			base = base.Add(c.Amounts[i])
+		}
+	}
+	return idx, base
+}
+
+// Spread 将优惠总额按当前应付金额比例分摊到 idx 所指的行，总额超过这些行的应付合计时取合计。
+func (c *PromotionContext) Spread(total decimal.Decimal, idx []int) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(c.Cart.Lines))
+	for i := range out {
+		out[i] = decimal.Zero
+	}
+	weights := make([]decimal.Decimal, len(idx))
+	base := decimal.Zero
+	for k, i := range idx {
+		weights[k] = c.Amounts[i]
+		base = base.Add(c.Amounts[i])
+	}
+	total = decimal.Min(total.RoundDown(c.Precision), base)
+	parts, err := Allocate(total, weights, c.Precision, AllocLargestRemainder)
+	if err != nil {
+		return out
+	}
+	for k, i := range idx {
+		out[i] = parts[k]
+	}
+	return out
+}
+
+// FullReduction 满减，如满 300 减 50；MaxTimes 为 0 时只减一次，为正时每满 Threshold 再减、最多 MaxTimes 次，为负时不限次数。
+type FullReduction struct {
+	PromotionMeta
+	Threshold decimal.Decimal
+	Reduction decimal.Decimal
+	MaxTimes  int
+}
+
+// Apply 实现 PromotionRule。
+func (r FullReduction) Apply(c *PromotionContext) ([]decimal.Decimal, string, bool) {
+	idx, base := c.Eligible(r.Scope)
+	if !r.Threshold.IsPositive() || !r.Reduction.IsPositive() || base.LessThan(r.Threshold) {
+		return nil, "", false
+	}
+	times := base.Div(r.Threshold).IntPart()
+	switch {
+	case r.MaxTimes == 0:
+		times = 1
+	case r.MaxTimes > 0:
+		times = min(times, int64(r.MaxTimes))
+	}
+	saving := r.Reduction.Mul(decimal.NewFromInt(times))
+	savings := c.Spread(saving, idx)
+	label := r.label(fmt.Sprintf("满%s减%s", r.Threshold, r.Reduction))
+	if times > 1 {
+		return savings, fmt.Sprintf("%s，满足%d次，优惠%s", label, times, sumOf(savings).StringFixed(c.Precision)), true
+	}
+	return savings, fmt.Sprintf("%s，优惠%s", label, sumOf(savings).StringFixed(c.Precision)), true
+}
+
+// PercentOff 打折，Discount 如 85 表示 85 折；可设最低消费 Threshold 与优惠上限 MaxSaving（为 0 时不限）。
+type PercentOff struct {
+	PromotionMeta
+	Discount  decimal.Decimal
+	Threshold decimal.Decimal
+	MaxSaving decimal.Decimal
+}
+
+// Apply 实现 PromotionRule。
+func (r PercentOff) Apply(c *PromotionContext) ([]decimal.Decimal, string, bool) {
+	idx, base := c.Eligible(r.Scope)
+	if len(idx) == 0 || base.LessThan(r.Threshold) || r.Discount.GreaterThanOrEqual(decimal.NewFromInt(100)) {
+		return nil, "", false
+	}
+	saving := base.Mul(decimal.NewFromInt(100).Sub(r.Discount)).Div(decimal.NewFromInt(100)).Round(c.Precision)
+	if r.MaxSaving.IsPositive() {
+		saving = decimal.Min(saving, r.MaxSaving)
+	}
+	savings := c.Spread(saving, idx)
+	// 整十的折扣去掉末尾的 0，如 90 显示为 9折
+	discount := r.Discount
+	if ten := decimal.NewFromInt(10); discount.Mod(ten).IsZero() {
+		discount = discount.Div(ten)
+	}
+	label := r.label(discount.String() + "折")
+	return savings, fmt.Sprintf("%s，优惠%s", label, sumOf(savings).StringFixed(c.Precision)), true
+}
+
+// Coupon 固定金额券，应付满 Threshold 可用（为 0 时无门槛），抵扣 Amount。
+type Coupon struct {
+	PromotionMeta
+	Threshold decimal.Decimal
+	Amount    decimal.Decimal
+}
+
+// Apply 实现 PromotionRule。
+func (r Coupon) Apply(c *PromotionContext) ([]decimal.Decimal, string, bool) {
+	idx, base := c.Eligible(r.Scope)
+	if len(idx) == 0 || base.LessThan(r.Threshold) || !r.Amount.IsPositive() {
+		return nil, "", false
+	}
+	savings := c.Spread(r.Amount, idx)
+	def := r.Amount.String() + "元券"
+	if r.Threshold.IsPositive() {
+		def = fmt.Sprintf("满%s减%s券", r.Threshold, r.Amount)
+	}
+	return savings, fmt.Sprintf("%s，优惠%s", r.label(def), sumOf(savings).StringFixed(c.Precision)), true
+}
+
+// BuyXGetY 买 Buy 送 Free：范围内每 Buy+Free 件中最便宜的 Free 件免单。
+type BuyXGetY struct {
+	PromotionMeta
+	Buy  int
+	Free int
+}
+
+// Apply 实现 PromotionRule。
+func (r BuyXGetY) Apply(c *PromotionContext) ([]decimal.Decimal, string, bool) {
+	if r.Buy <= 0 || r.Free <= 0 {
+		return nil, "", false
+	}
+	idx, _ := c.Eligible(r.Scope)
+	qty := 0
+	for _, i := range idx {
+		qty += c.Cart.Lines[i].Quantity
+	}
+	free := qty / (r.Buy + r.Free) * r.Free
+	if free == 0 {
+		return nil, "", false
+	}
+	// 按当前单价从低到高免单
+	slices.SortStableFunc(idx, func(a, b int) int {
+		return c.unitAmount(a).Cmp(c.unitAmount(b))
+	})
+	savings := make([]decimal.Decimal, len(c.Cart.Lines))
+	for i := range savings {
+		savings[i] = decimal.Zero
+	}
+	left := free
+	for _, i := range idx {
+		n := min(left, c.Cart.Lines[i].Quantity)
+		if n == 0 {
+			break
+		}
+		if n == c.Cart.Lines[i].Quantity {
+			savings[i] = c.Amounts[i]
+		} else {
+			savings[i] = c.unitAmount(i).Mul(decimal.NewFromInt(int64(n))).RoundDown(c.Precision)
+		}
+		left -= n
+	}
+	label := r.label(fmt.Sprintf("买%d送%d", r.Buy, r.Free))
+	return savings, fmt.Sprintf("%s，赠送%d件，优惠%s", label, free, sumOf(savings).StringFixed(c.Precision)), true
+}
+
+// unitAmount 返回第 i 行当前应付单价。
+func (c *PromotionContext) unitAmount(i int) decimal.Decimal {
+	return c.Amounts[i].Div(decimal.NewFromInt(int64(c.Cart.Lines[i].Quantity)))
+}
+
+// MemberPrice 会员价，Prices 为 SKU 到会员单价的映射，仅 Cart.Member 为 true 时生效。
+type MemberPrice struct {
+	PromotionMeta
+	Prices map[string]decimal.Decimal
+}
+
+// Apply 实现 PromotionRule。
+func (r MemberPrice) Apply(c *PromotionContext) ([]decimal.Decimal, string, bool) {
+	if !c.Cart.Member {
+		return nil, "", false
+	}
+	idx, _ := c.Eligible(r.Scope)
+	savings := make([]decimal.Decimal, len(c.Cart.Lines))
+	for i := range savings {
+		savings[i] = decimal.Zero
+	}
+	total := decimal.Zero
+	for _, i := range idx {
+		l := c.Cart.Lines[i]
+		p, ok := r.Prices[l.SKU]
+		if !ok {
+			continue
+		}
+		s := c.Amounts[i].Sub(p.Mul(decimal.NewFromInt(int64(l.Quantity)))).Round(c.Precision)
+		if s.IsPositive() {
+			savings[i] = decimal.Min(s, c.Amounts[i])
+			total = total.Add(savings[i])
+		}
+	}
+	if !total.IsPositive() {
+		return nil, "", false
+	}
+	return savings, fmt.Sprintf("%s，优惠%s", r.label("会员价"), total.StringFixed(c.Precision)), true
+}
+
+// AppliedPromotion 已使用的一条优惠。
+type AppliedPromotion struct {
+	ID          string
+	Saving      decimal.Decimal
+	Explanation string
+	LineSavings []decimal.Decimal // 各行分摊到的优惠额
+}
+
+// LineResult 单行的优惠结果。
+type LineResult struct {
+	SKU      string
+	Original decimal.Decimal
+	Saving   decimal.Decimal
+	Payable  decimal.Decimal
+}
+
+// PromotionResult 优惠计算结果。
+type PromotionResult struct {
+	Original decimal.Decimal
+	Saving   decimal.Decimal
+	Payable  decimal.Decimal
+	Applied  []AppliedPromotion // 按计算顺序排列
+	Lines    []LineResult
+}
+
+// Explanations 返回各条优惠的说明，便于展示给用户。
+func (r PromotionResult) Explanations() []string {
+	out := make([]string, len(r.Applied))
+	for i, a := range r.Applied {
+		out[i] = a.Explanation
+	}
+	return out
+}
+
+type promotionOpts struct {
+	precision int32
+}
+
+// PromotionOpts 创建优惠计算选项，默认金额精度为 2 位小数。
+func PromotionOpts() *promotionOpts {
+	return &promotionOpts{precision: 2}
+}
+
+// Precision 设置金额精度，如日元为 0。
+func (o *promotionOpts) Precision(p int32) *promotionOpts {
+	o.precision = p
+	return o
+}
+
+func resolvePromotionOpts(opts ...*promotionOpts) *promotionOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return PromotionOpts()
+	}
+	return opts[0]
+}
+
+// ApplyPromotions 按 Priority（相同时按传入顺序）依次叠加计算给定规则，不检查互斥，不满足条件的规则跳过。
+func ApplyPromotions(cart Cart, rules []PromotionRule, opts ...*promotionOpts) PromotionResult {
+	o := resolvePromotionOpts(opts...)
+	ordered := slices.Clone(rules)
+	slices.SortStableFunc(ordered, func(a, b PromotionRule) int { return a.Meta().Priority - b.Meta().Priority })
+
+	c := &PromotionContext{Cart: cart, Amounts: make([]decimal.Decimal, len(cart.Lines)), Precision: o.precision}
+	res := PromotionResult{Original: decimal.Zero, Saving: decimal.Zero}
+	for i, l := range cart.Lines {
+		c.Amounts[i] = l.Amount()
+		res.Original = res.Original.Add(c.Amounts[i])
+	}
+	for _, r := range ordered {
+		savings, explain, ok := r.Apply(c)
+		if !ok || len(savings) != len(cart.Lines) {
+			continue
+		}
+		// 各行优惠限制在 [0, 当前应付] 内，自定义规则返回负数或超额时也不会使应付变多或为负
+		applied := AppliedPromotion{ID: r.Meta().ID, Saving: decimal.Zero, Explanation: explain, LineSavings: savings}
+		for i, s := range savings {
+			savings[i] = decimal.Max(decimal.Zero, decimal.Min(s, c.Amounts[i]))
+			applied.Saving = applied.Saving.Add(savings[i])
+		}
+		if !applied.Saving.IsPositive() {
+			continue
+		}
+		for i, s := range savings {
+			c.Amounts[i] = c.Amounts[i].Sub(s)
+		}
+		res.Applied = append(res.Applied, applied)
+		res.Saving = res.Saving.Add(applied.Saving)
+	}
+	res.Payable = res.Original.Sub(res.Saving)
+	res.Lines = make([]LineResult, len(cart.Lines))
+	for i, l := range cart.Lines {
+		orig := l.Amount()
+		res.Lines[i] = LineResult{SKU: l.SKU, Original: orig, Saving: orig.Sub(c.Amounts[i]), Payable: c.Amounts[i]}
+	}
+	return res
+}
+
+// maxPromotionCombos 最优组合搜索的组合数上限。
+const maxPromotionCombos = 1 << 16
+
+// BestPromotions 在满足互斥组与独占约束的所有规则组合中，选出优惠总额最大的组合并返回其计算结果；
+// 优惠相同时取使用规则较少的组合。同一互斥组的规则最多选一条，组合数按组计算，
+// 超过 65536 种时返回 ErrTooManyPromotions，应先按业务筛掉不可能使用的规则。
+func BestPromotions(cart Cart, rules []PromotionRule, opts ...*promotionOpts) (PromotionResult, error) {
+	// groups 为各互斥组的规则下标，无组的规则各自成组；独占规则只能单独使用
+	var groups [][]int
+	var exclusive []int
+	groupIndex := map[string]int{}
+	for i, r := range rules {
+		m := r.Meta()
+		switch {
+		case m.Exclusive:
+			exclusive = append(exclusive, i)
+		case m.Group == "":
+			groups = append(groups, []int{i})
+		default:
+			k, ok := groupIndex[m.Group]
+			if !ok {
+				k = len(groups)
+				groupIndex[m.Group] = k
+				groups = append(groups, nil)
+			}
+			groups[k] = append(groups[k], i)
+		}
+	}
+	combos := 1
+	for _, g := range groups {
+		if combos *= len(g) + 1; combos > maxPromotionCombos {
+			return PromotionResult{}, fmt.Errorf("%w: %d 条规则的组合超过 %d 种", ErrTooManyPromotions, len(rules), maxPromotionCombos)
+		}
+	}
+
+	best := ApplyPromotions(cart, nil, opts...)
+	bestCount := 0
+	try := func(subset []PromotionRule) {
+		res := ApplyPromotions(cart, subset, opts...)
+		if cmp := res.Saving.Cmp(best.Saving); cmp > 0 || cmp == 0 && len(res.Applied) < bestCount {
+			best, bestCount = res, len(res.Applied)
+		}
+	}
+	for _, i := range exclusive {
+		try([]PromotionRule{rules[i]})
+	}
+	// choice[g] 为 0 表示第 g 组不选，为 k 表示选该组第 k 条，按多进制计数遍历全部组合
+	choice := make([]int, len(groups))
+	for {
+		g := 0
+		for ; g < len(choice); g++ {
+			if choice[g]++; choice[g] <= len(groups[g]) {
+				break
+			}
+			choice[g] = 0
+		}
+		if g == len(choice) {
+			return best, nil
+		}
+		var idx []int
+		for g, k := range choice {
+			if k > 0 {
+				idx = append(idx, groups[g][k-1])
+			}
+		}
+		// 保持传入顺序，使 Priority 相同的规则按传入顺序计算
+		slices.Sort(idx)
+		subset := make([]PromotionRule, len(idx))
+		for k, i := range idx {
+			subset[k] = rules[i]
+		}
+		try(subset)
+	}
+}
+
+func sumOf(list []decimal.Decimal) decimal.Decimal {
+	return decimal.Sum(decimal.Zero, list...)
+}
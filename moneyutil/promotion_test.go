@@ -0,0 +1,183 @@
+package moneyutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func testCart() Cart {
+	return Cart{Lines: []CartLine{
+		{SKU: "A", Category: "food", Price: mustDec("100"), Quantity: 2},
+		{SKU: "B", Category: "food", Price: mustDec("80"), Quantity: 1},
+		{SKU: "C", Category: "drink", Price: mustDec("30"), Quantity: 3},
+	}}
+}
+
+func assertLinesBalance(t *testing.T, r PromotionResult) {
+	t.Helper()
+	saving, payable := decimal.Zero, decimal.Zero
+	for _, l := range r.Lines {
+		saving = saving.Add(l.Saving)
+		payable = payable.Add(l.Payable)
+		assert.False(t, l.Payable.IsNegative(), l.SKU)
+	}
+	assert.True(t, r.Saving.Equal(saving), "saving %s != %s", r.Saving, saving)
+	assert.True(t, r.Payable.Equal(payable), "payable %s != %s", r.Payable, payable)
+	assert.True(t, r.Original.Equal(r.Saving.Add(r.Payable)))
+}
+
+func TestApplyPromotions(t *testing.T) {
+	as := assert.New(t)
+	full := FullReduction{PromotionMeta: PromotionMeta{ID: "full", Priority: 2}, Threshold: mustDec("300"), Reduction: mustDec("50")}
+	off := PercentOff{PromotionMeta: PromotionMeta{ID: "off", Priority: 1}, Discount: mustDec("90")}
+
+	// 满减单独使用：50 按 200/80/90 分摊
+	r := ApplyPromotions(testCart(), []PromotionRule{full})
+	as.Equal("370", r.Original.String())
+	as.Equal("50", r.Saving.String())
+	as.Equal([]string{"满300减50，优惠50.00"}, r.Explanations())
+	as.Equal([]string{"27.03", "10.81", "12.16"}, []string{r.Lines[0].Saving.String(), r.Lines[1].Saving.String(), r.Lines[2].Saving.String()})
+	assertLinesBalance(t, r)
+
+	// 先 9 折再满减：370 → 333 → 283
+	r = ApplyPromotions(testCart(), []PromotionRule{full, off})
+	as.Equal("283", r.Payable.String())
+	as.Equal([]string{"9折，优惠37.00", "满300减50，优惠50.00"}, r.Explanations())
+	assertLinesBalance(t, r)
+
+	// 每满 100 减 10，最多 2 次
+	r = ApplyPromotions(testCart(), []PromotionRule{FullReduction{Threshold: mustDec("100"), Reduction: mustDec("10"), MaxTimes: 2}})
+	as.Equal("20", r.Saving.String())
+	as.Equal("满100减10，满足2次，优惠20.00", r.Applied[0].Explanation)
+	r = ApplyPromotions(testCart(), []PromotionRule{FullReduction{Threshold: mustDec("100"), Reduction: mustDec("10"), MaxTimes: -1}})
+	as.Equal("30", r.Saving.String())
+}
+
+func TestPromotionRules(t *testing.T) {
+	as := assert.New(t)
+	drinks := PromotionScope{Categories: []string{"drink"}}
+
+	r := ApplyPromotions(testCart(), []PromotionRule{BuyXGetY{PromotionMeta: PromotionMeta{Scope: drinks}, Buy: 2, Free: 1}})
+	as.Equal("30", r.Saving.String())
+	as.Equal("买2送1，赠送1件，优惠30.00", r.Applied[0].Explanation)
+	as.Equal("60", r.Lines[2].Payable.String())
+	assertLinesBalance(t, r)
+
+	coupon := Coupon{PromotionMeta: PromotionMeta{Name: "新人券", Scope: PromotionScope{SKUs: []string{"B"}}}, Threshold: mustDec("100"), Amount: mustDec("20")}
+	r = ApplyPromotions(testCart(), []PromotionRule{coupon})
+	as.Empty(r.Applied, "B 只有 80，不满 100")
+	coupon.Threshold = mustDec("50")
+	r = ApplyPromotions(testCart(), []PromotionRule{coupon})
+	as.Equal([]string{"新人券，优惠20.00"}, r.Explanations())
+	as.Equal("20", r.Lines[1].Saving.String())
+
+	member := MemberPrice{Prices: map[string]decimal.Decimal{"A": mustDec("90")}}
+	r = ApplyPromotions(testCart(), []PromotionRule{member})
+	as.Empty(r.Applied)
+	cart := testCart()
+	cart.Member = true
+	r = ApplyPromotions(cart, []PromotionRule{member})
+	as.Equal([]string{"会员价，优惠20.00"}, r.Explanations())
+
+	capped := PercentOff{Discount: mustDec("50"), MaxSaving: mustDec("100")}
+	r = ApplyPromotions(testCart(), []PromotionRule{capped})
+	as.Equal("100", r.Saving.String())
+	assertLinesBalance(t, r)
+}
+
+// fixedSavings 直接返回给定各行优惠的自定义规则，用于测试越界的优惠额。
+type fixedSavings struct {
+	PromotionMeta
+	savings []string
+}
+
+func (r fixedSavings) Apply(c *PromotionContext) ([]decimal.Decimal, string, bool) {
+	out := make([]decimal.Decimal, len(r.savings))
+	for i, s := range r.savings {
+		out[i] = mustDec(s)
+	}
+	return out, "自定义", true
+}
+
+func TestApplyPromotions_clamp(t *testing.T) {
+	as := assert.New(t)
+
+	// 负的满减不生效
+	r := ApplyPromotions(testCart(), []PromotionRule{FullReduction{Threshold: mustDec("50"), Reduction: mustDec("-10")}})
+	as.Empty(r.Applied)
+	as.Equal("370", r.Payable.String())
+	assertLinesBalance(t, r)
+
+	// 负数优惠按 0 计，超额优惠不超过该行应付
+	r = ApplyPromotions(testCart(), []PromotionRule{fixedSavings{savings: []string{"-10", "100", "5"}}})
+	as.Equal("85", r.Saving.String())
+	as.Equal([]string{"0", "80", "5"}, []string{r.Lines[0].Saving.String(), r.Lines[1].Saving.String(), r.Lines[2].Saving.String()})
+	assertLinesBalance(t, r)
+
+	r = ApplyPromotions(testCart(), []PromotionRule{fixedSavings{savings: []string{"-10", "0", "0"}}})
+	as.Empty(r.Applied)
+	as.Equal("370", r.Payable.String())
+	assertLinesBalance(t, r)
+
+	r = ApplyPromotions(testCart(), []PromotionRule{PercentOff{Discount: mustDec("95")}})
+	as.Equal("95折，优惠18.50", r.Applied[0].Explanation)
+}
+
+func TestBestPromotions(t *testing.T) {
+	as := assert.New(t)
+	rules := []PromotionRule{
+		PercentOff{PromotionMeta: PromotionMeta{ID: "off90", Group: "折扣", Priority: 1}, Discount: mustDec("90")},
+		PercentOff{PromotionMeta: PromotionMeta{ID: "off80", Group: "折扣", Priority: 1}, Discount: mustDec("80")},
+		FullReduction{PromotionMeta: PromotionMeta{ID: "full", Priority: 2}, Threshold: mustDec("300"), Reduction: mustDec("50")},
+	}
+	// 8 折后 296 不满 300，9 折加满减反而更优：37 + 50 > 74
+	r, err := BestPromotions(testCart(), rules)
+	require.NoError(t, err)
+	as.Equal("87", r.Saving.String())
+	as.Equal([]string{"off90", "full"}, []string{r.Applied[0].ID, r.Applied[1].ID})
+
+	clearance := PercentOff{PromotionMeta: PromotionMeta{ID: "clear", Name: "清仓5折", Exclusive: true}, Discount: mustDec("50")}
+	r, err = BestPromotions(testCart(), append(rules, clearance))
+	require.NoError(t, err)
+	as.Equal("185", r.Saving.String())
+	as.Equal([]string{"清仓5折，优惠185.00"}, r.Explanations())
+	assertLinesBalance(t, r)
+
+	r, err = BestPromotions(testCart(), nil)
+	require.NoError(t, err)
+	as.True(r.Saving.IsZero())
+	as.Equal("370", r.Payable.String())
+}
+
+func TestBestPromotions_limit(t *testing.T) {
+	as := assert.New(t)
+
+	// 同组规则只选其一，30 条券分 2 组只有 16 × 16 种组合
+	var rules []PromotionRule
+	for i := range 30 {
+		rules = append(rules, Coupon{
+			PromotionMeta: PromotionMeta{ID: fmt.Sprint(i), Group: fmt.Sprint(i % 2)},
+			Amount:        decimal.NewFromInt(int64(i + 1)),
+		})
+	}
+	r, err := BestPromotions(testCart(), rules)
+	require.NoError(t, err)
+	as.Equal("59", r.Saving.String())
+	as.Equal([]string{"28", "29"}, []string{r.Applied[0].ID, r.Applied[1].ID})
+
+	// 17 条无组规则有 2^17 种组合，超过上限时报错而不是忽略多出的规则
+	rules = rules[:0]
+	for i := range 17 {
+		rules = append(rules, Coupon{PromotionMeta: PromotionMeta{ID: fmt.Sprint(i)}, Amount: decimal.NewFromInt(1)})
+	}
+	_, err = BestPromotions(testCart(), rules)
+	as.ErrorIs(err, ErrTooManyPromotions)
+}
@@ -0,0 +1,108 @@
+package tax
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// SpecialDeduction 个人所得税专项附加扣除项目。
+type SpecialDeduction string
+
+const (
+	ChildEducation      SpecialDeduction = "子女教育"      // 每个子女
+	InfantCare          SpecialDeduction = "3岁以下婴幼儿照护" // 每个婴幼儿
+	ContinuingEducation SpecialDeduction = "继续教育"      // 学历（学位）继续教育
+	HousingLoanInterest SpecialDeduction = "住房贷款利息"
+	HousingRentTier1    SpecialDeduction = "住房租金（直辖市、省会等城市）"
+	HousingRentTier2    SpecialDeduction = "住房租金（户籍人口超过100万的城市）"
+	HousingRentTier3    SpecialDeduction = "住房租金（其他城市）"
+	ElderlySupport      SpecialDeduction = "赡养老人" // 独生子女标准，非独生子女按分摊额覆盖
+)
+
+// IITConfig 个人所得税综合所得的计算参数，可复制后修改以适应政策调整。
+type IITConfig struct {
+	Brackets         BracketTable                         // 年度综合所得税率表
+	MonthlyBasic     decimal.Decimal                      // 每月基本减除费用
+	SpecialStandards map[SpecialDeduction]decimal.Decimal // 专项附加扣除每月标准
+	Precision        int32                                // 税额精度
+}
+
+// DefaultIITConfig 返回现行中国个人所得税参数：综合所得 3%~45% 七级税率、每月 5000 元基本减除费用，
+// 以及 2023 年起施行的专项附加扣除标准。每次调用返回新的副本。
+func DefaultIITConfig() IITConfig {
+	dec := func(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+	return IITConfig{
+		Brackets: BracketTable{
+			{UpTo: dec("36000"), Rate: dec("0.03")},
+			{UpTo: dec("144000"), Rate: dec("0.10")},
+			{UpTo: dec("300000"), Rate: dec("0.20")},
+			{UpTo: dec("420000"), Rate: dec("0.25")},
+			{UpTo: dec("660000"), Rate: dec("0.30")},
+			{UpTo: dec("960000"), Rate: dec("0.35")},
+			{Rate: dec("0.45")},
+		},
+		MonthlyBasic: dec("5000"),
+		SpecialStandards: map[SpecialDeduction]decimal.Decimal{
+			ChildEducation:      dec("2000"),
+			InfantCare:          dec("2000"),
+			ContinuingEducation: dec("400"),
+			HousingLoanInterest: dec("1000"),
+			HousingRentTier1:    dec("1500"),
+			HousingRentTier2:    dec("1100"),
+			HousingRentTier3:    dec("800"),
+			ElderlySupport:      dec("3000"),
+		},
+		Precision: 2,
+	}
+}
+
+// MonthlySpecial 按项目与数量（如子女人数）返回每月专项附加扣除合计，未配置标准的项目忽略。
+func (c IITConfig) MonthlySpecial(items map[SpecialDeduction]int) decimal.Decimal {
+	total := decimal.Zero
+	for item, n := range items {
+		if std, ok := c.SpecialStandards[item]; ok && n > 0 {
+			total = total.Add(std.Mul(decimal.NewFromInt(int64(n))))
+		}
+	}
+	return total
+}
+
+// MonthlyPay 一个月的工资薪金及扣除。
+type MonthlyPay struct {
+	Income          decimal.Decimal // 工资薪金收入
+	SocialInsurance decimal.Decimal // 个人缴纳的三险一金等专项扣除
+	Special         decimal.Decimal // 专项附加扣除，可由 MonthlySpecial 计算
+	Other           decimal.Decimal // 依法确定的其他扣除，如个人养老金
+}
+
+// Withholding 某月的累计预扣结果。
+type Withholding struct {
+	Month             int             // 第几个月，从 1 开始
+	CumulativeTaxable decimal.Decimal // 累计预扣预缴应纳税所得额
+	Rate              decimal.Decimal // 适用预扣率
+	QuickDeduction    decimal.Decimal // 速算扣除数
+	CumulativeTax     decimal.Decimal // 累计应预扣预缴税额
+	Tax               decimal.Decimal // 本月应预扣预缴税额
+}
+
+// Withhold 按累计预扣法计算一个纳税年度内逐月应预扣的个人所得税，months 为自 1 月（或入职当月）起的各月数据。
+// 本月预扣额 = 累计应纳税额 - 已预扣额，为负时本月不预扣，差额留待汇算清缴退税。
+func (c IITConfig) Withhold(months []MonthlyPay) []Withholding {
+	out := make([]Withholding, len(months))
+	cumTaxable, withheld := decimal.Zero, decimal.Zero
+	for i, m := range months {
+		cumTaxable = cumTaxable.Add(m.Income).Sub(c.MonthlyBasic).Sub(m.SocialInsurance).Sub(m.Special).Sub(m.Other)
+		taxable := decimal.Max(cumTaxable, decimal.Zero)
+		_, rate, quick := c.Brackets.BracketOf(taxable)
+		cumTax := c.Brackets.Tax(taxable).Round(c.Precision)
+		tax := decimal.Max(cumTax.Sub(withheld), decimal.Zero)
+		withheld = withheld.Add(tax)
+		out[i] = Withholding{Month: i + 1, CumulativeTaxable: taxable, Rate: rate, QuickDeduction: quick, CumulativeTax: cumTax, Tax: tax}
+	}
+	return out
+}
+
+// AnnualTax 计算年度综合所得应纳税额，deductions 为全年各项扣除合计（不含基本减除费用）。
+func (c IITConfig) AnnualTax(income, deductions decimal.Decimal) decimal.Decimal {
+	taxable := income.Sub(c.MonthlyBasic.Mul(decimal.NewFromInt(12))).Sub(deductions)
+	return c.Brackets.Tax(taxable).Round(c.Precision)
+}
@@ -0,0 +1,80 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBracketTable(t *testing.T) {
+	as := assert.New(t)
+	table := DefaultIITConfig().Brackets
+	cases := []struct {
+		taxable string
+		idx     int
+		quick   string
+		tax     string
+	}{
+		{"0", 0, "0", "0"},
+		{"36000", 0, "0", "1080"},
+		{"36000.01", 1, "2520", "1080.001"},
+		{"144000", 1, "2520", "11880"},
+		{"200000", 2, "16920", "23080"},
+		{"420000", 3, "31920", "73080"},
+		{"660000", 4, "52920", "145080"},
+		{"960000", 5, "85920", "250080"},
+		{"1000000", 6, "181920", "268080"},
+	}
+	for _, c := range cases {
+		idx, _, quick := table.BracketOf(mustDec(c.taxable))
+		as.Equal(c.idx, idx, c.taxable)
+		as.Equal(c.quick, quick.String(), c.taxable)
+		as.Equal(c.tax, table.Tax(mustDec(c.taxable)).String(), c.taxable)
+	}
+	as.True(table.Tax(mustDec("-100")).IsZero())
+	as.True(BracketTable(nil).Tax(mustDec("100")).IsZero())
+}
+
+func TestWithhold(t *testing.T) {
+	as := assert.New(t)
+	c := DefaultIITConfig()
+	months := make([]MonthlyPay, 12)
+	for i := range months {
+		months[i] = MonthlyPay{Income: mustDec("20000")}
+	}
+	w := c.Withhold(months)
+	// 累计应纳税所得额每月增加 15000，第 3 个月跨入 10% 档
+	as.Equal("450", w[0].Tax.String())
+	as.Equal("450", w[1].Tax.String())
+	as.Equal("1080", w[2].Tax.String())
+	as.Equal("45000", w[2].CumulativeTaxable.String())
+	as.Equal("0.1", w[2].Rate.String())
+	as.Equal("2520", w[2].QuickDeduction.String())
+	total := decimal.Zero
+	for _, m := range w {
+		total = total.Add(m.Tax)
+	}
+	as.True(total.Equal(w[11].CumulativeTax))
+	as.True(total.Equal(c.AnnualTax(mustDec("240000"), decimal.Zero)), total.String())
+
+	// 专项附加扣除：1 个子女 + 赡养老人 + 住房贷款利息
+	special := c.MonthlySpecial(map[SpecialDeduction]int{ChildEducation: 1, ElderlySupport: 1, HousingLoanInterest: 1, "未知项目": 1})
+	as.Equal("6000", special.String())
+	w = c.Withhold([]MonthlyPay{
+		{Income: mustDec("15000"), SocialInsurance: mustDec("2000"), Special: special},
+		{Income: mustDec("3000")},
+		{Income: mustDec("30000"), SocialInsurance: mustDec("2000"), Special: special},
+	})
+	as.Equal("60", w[0].Tax.String())
+	as.Equal("0", w[1].Tax.String(), "累计所得减少时本月不预扣")
+	as.Equal("0", w[1].CumulativeTax.String())
+	as.Equal("450", w[2].Tax.String())
+
+	// 覆盖默认参数
+	c.MonthlyBasic = mustDec("8000")
+	c.SpecialStandards[ElderlySupport] = mustDec("1500")
+	as.Equal("1500", c.MonthlySpecial(map[SpecialDeduction]int{ElderlySupport: 1}).String())
+	as.Equal("360", c.Withhold([]MonthlyPay{{Income: mustDec("20000")}})[0].Tax.String())
+	as.Equal("3000", DefaultIITConfig().SpecialStandards[ElderlySupport].String())
+}
@@ -0,0 +1,38 @@
+package tax
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// Bracket 累进税率表的一档：应纳税所得额不超过 UpTo 的部分适用 Rate，最后一档 UpTo 为零值表示不设上限。
+type Bracket struct {
+	UpTo decimal.Decimal
+	Rate decimal.Decimal
+}
+
+// BracketTable 按 UpTo 升序排列的超额累进税率表。
+type BracketTable []Bracket
+
+// BracketOf 返回应纳税所得额 taxable 适用的档次下标、税率与速算扣除数。
+func (t BracketTable) BracketOf(taxable decimal.Decimal) (int, decimal.Decimal, decimal.Decimal) {
+	quick := decimal.Zero
+	prevUpTo, prevRate := decimal.Zero, decimal.Zero
+	for i, b := range t {
+		// 速算扣除数 = 上一档速算扣除数 + 上一档上限 × (本档税率 - 上一档税率)
+		quick = quick.Add(prevUpTo.Mul(b.Rate.Sub(prevRate)))
+		if b.UpTo.IsZero() || taxable.LessThanOrEqual(b.UpTo) || i == len(t)-1 {
+			return i, b.Rate, quick
+		}
+		prevUpTo, prevRate = b.UpTo, b.Rate
+	}
+	return -1, decimal.Zero, decimal.Zero
+}
+
+// Tax 按超额累进计算应纳税额，taxable 不为正时为 0，结果未舍入。
+func (t BracketTable) Tax(taxable decimal.Decimal) decimal.Decimal {
+	if !taxable.IsPositive() || len(t) == 0 {
+		return decimal.Zero
+	}
+	_, rate, quick := t.BracketOf(taxable)
+	return taxable.Mul(rate).Sub(quick)
+}
@@ -0,0 +1,169 @@
+// Package tax 提供增值税价税分离与合并、累进税率计算与中国个人所得税累计预扣工具。
+package tax
+
+import (
+	"github.com/lontten/lutil/moneyutil"
+	"github.com/shopspring/decimal"
+)
+
+// 常用增值税税率。
+var (
+	VAT13 = decimal.RequireFromString("0.13")
+	VAT9  = decimal.RequireFromString("0.09")
+	VAT6  = decimal.RequireFromString("0.06")
+	VAT3  = decimal.RequireFromString("0.03")
+	VAT1  = decimal.RequireFromString("0.01")
+)
+
+// VATLine 一行应税金额，Amount 为含税或不含税金额，取决于调用 Split 还是 Merge。
+type VATLine struct {
+	Amount decimal.Decimal
+	Rate   decimal.Decimal // 税率，如 VAT13
+}
+
+// VATResult 一行或合计的价税金额，Gross = Net + Tax。
+type VATResult struct {
+	Net   decimal.Decimal // 不含税金额
+	Tax   decimal.Decimal // 税额
+	Gross decimal.Decimal // 价税合计
+	Rate  decimal.Decimal // 合计行为零值
+}
+
+// VATSummary 多行价税计算结果。
+type VATSummary struct {
+	Lines []VATResult
+	Total VATResult
+}
+
+type vatOpts struct {
+	precision int32
+	reconcile bool
+}
+
+// VATOpts 创建增值税选项，默认精度 2 位小数，并按税率分组消除尾差。
+func VATOpts() *vatOpts {
+	return &vatOpts{precision: 2, reconcile: true}
+}
+
+// Precision 设置金额精度。
+func (o *vatOpts) Precision(p int32) *vatOpts {
+	o.precision = p
+	return o
+}
+
+// Reconcile 设置是否消除尾差：为 true 时同一税率各行的税额之和等于按该税率合计金额计算的税额，
+// 差额按最大余额法分摊到各行；为 false 时各行独立舍入。
+func (o *vatOpts) Reconcile(b bool) *vatOpts {
+	o.reconcile = b
+	return o
+}
+
+func resolveVATOpts(opts ...*vatOpts) *vatOpts {
+	if len(opts) == 0 || opts[0] == nil {
+		return VATOpts()
+	}
+	return opts[0]
+}
+
+// SplitVAT 价税分离：由含税金额 gross 计算不含税金额与税额，税额 = gross / (1 + rate) × rate。
+func SplitVAT(gross, rate decimal.Decimal, precision int32) VATResult {
+	tax := taxOfGross(gross, rate).Round(precision)
+	return VATResult{Net: gross.Sub(tax), Tax: tax, Gross: gross, Rate: rate}
+}
+
+// MergeVAT 价税合并：由不含税金额 net 计算税额与价税合计，税额 = net × rate。
+func MergeVAT(net, rate decimal.Decimal, precision int32) VATResult {
+	tax := net.Mul(rate).Round(precision)
+	return VATResult{Net: net, Tax: tax, Gross: net.Add(tax), Rate: rate}
+}
+
+// Split 对多行含税金额做价税分离，如由含税售价开具发票。
+func Split(lines []VATLine, opts ...*vatOpts) VATSummary {
+	return calcLines(lines, true, resolveVATOpts(opts...))
+}
+
+// Merge 对多行不含税金额做价税合并。
+func Merge(lines []VATLine, opts ...*vatOpts) VATSummary {
+	return calcLines(lines, false, resolveVATOpts(opts...))
+}
+
+func taxOfGross(gross, rate decimal.Decimal) decimal.Decimal {
+	return gross.Mul(rate).DivRound(decimal.NewFromInt(1).Add(rate), 16)
+}
+
+func calcLines(lines []VATLine, inclusive bool, o *vatOpts) VATSummary {
+	sum := VATSummary{Lines: make([]VATResult, len(lines))}
+	taxes := make([]decimal.Decimal, len(lines))
+	for i, l := range lines {
+		if inclusive {
+			taxes[i] = taxOfGross(l.Amount, l.Rate).Round(o.precision)
+		} else {
+			taxes[i] = l.Amount.Mul(l.Rate).Round(o.precision)
+		}
+	}
+
+	if o.reconcile {
+		// 按税率分组，组内税额按合计金额计算后再分摊
+		groups := map[string][]int{}
+		var order []string
+		for i, l := range lines {
+			key := l.Rate.String()
+			if _, ok := groups[key]; !ok {
+				order = append(order, key)
+			}
+			groups[key] = append(groups[key], i)
+		}
+		for _, key := range order {
+			idx := groups[key]
+			rate := lines[idx[0]].Rate
+			total := decimal.Zero
+			weights := make([]decimal.Decimal, len(idx))
+			for k, i := range idx {
+				total = total.Add(lines[i].Amount)
+				weights[k] = lines[i].Amount.Abs()
+			}
+			var groupTax decimal.Decimal
+			if inclusive {
+				groupTax = taxOfGross(total, rate).Round(o.precision)
+			} else {
+				groupTax = total.Mul(rate).Round(o.precision)
+			}
+			reconcileTaxes(taxes, idx, groupTax, weights, o.precision)
+		}
+	}
+
+	sum.Total = VATResult{Net: decimal.Zero, Tax: decimal.Zero, Gross: decimal.Zero}
+	for i, l := range lines {
+		r := VATResult{Tax: taxes[i], Rate: l.Rate}
+		if inclusive {
+			r.Gross, r.Net = l.Amount, l.Amount.Sub(taxes[i])
+		} else {
+			r.Net, r.Gross = l.Amount, l.Amount.Add(taxes[i])
+		}
+		sum.Lines[i] = r
+		sum.Total.Net = sum.Total.Net.Add(r.Net)
+		sum.Total.Tax = sum.Total.Tax.Add(r.Tax)
+		sum.Total.Gross = sum.Total.Gross.Add(r.Gross)
+	}
+	return sum
+}
+
+// reconcileTaxes 将组内各行税额调整为合计 groupTax：各行舍入后的差额以最小单位分摊给金额较大的行。
+func reconcileTaxes(taxes []decimal.Decimal, idx []int, groupTax decimal.Decimal, weights []decimal.Decimal, precision int32) {
+	current := decimal.Zero
+	for _, i := range idx {
+		current = current.Add(taxes[i])
+	}
+	diff := groupTax.Sub(current)
+	if diff.IsZero() {
+		return
+	}
+	// 金额全为 0 时 Allocate 返回错误，此时各行税额均为 0，无需调整
+	parts, err := moneyutil.Allocate(diff, weights, precision, moneyutil.AllocLargestRemainder)
+	if err != nil {
+		return
+	}
+	for k, i := range idx {
+		taxes[i] = taxes[i].Add(parts[k])
+	}
+}
@@ -0,0 +1,63 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustDec(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestSplitMergeVAT(t *testing.T) {
+	as := assert.New(t)
+	r := SplitVAT(mustDec("113"), VAT13, 2)
+	as.Equal("100", r.Net.String())
+	as.Equal("13", r.Tax.String())
+
+	r = SplitVAT(mustDec("100"), VAT6, 2)
+	as.Equal("94.34", r.Net.String())
+	as.Equal("5.66", r.Tax.String())
+
+	r = MergeVAT(mustDec("99.99"), VAT9, 2)
+	as.Equal("9", r.Tax.String())
+	as.Equal("108.99", r.Gross.String())
+}
+
+func TestSplitReconcile(t *testing.T) {
+	as := assert.New(t)
+	lines := []VATLine{
+		{Amount: mustDec("1"), Rate: VAT13},
+		{Amount: mustDec("1"), Rate: VAT13},
+		{Amount: mustDec("1"), Rate: VAT13},
+		{Amount: mustDec("106"), Rate: VAT6},
+	}
+
+	// 各行独立舍入：0.12 × 3 = 0.36，与按合计 3 元计算的 0.35 差 1 分
+	s := Split(lines, VATOpts().Reconcile(false))
+	as.Equal("6.36", s.Total.Tax.String())
+
+	s = Split(lines)
+	as.Equal("6.35", s.Total.Tax.String())
+	as.Equal([]string{"0.11", "0.12", "0.12", "6"},
+		[]string{s.Lines[0].Tax.String(), s.Lines[1].Tax.String(), s.Lines[2].Tax.String(), s.Lines[3].Tax.String()})
+	as.Equal("109", s.Total.Gross.String())
+	as.True(s.Total.Gross.Equal(s.Total.Net.Add(s.Total.Tax)))
+	for _, l := range s.Lines {
+		as.True(l.Gross.Equal(l.Net.Add(l.Tax)))
+	}
+
+	m := Merge([]VATLine{
+		{Amount: mustDec("0.05"), Rate: VAT9},
+		{Amount: mustDec("0.05"), Rate: VAT9},
+		{Amount: mustDec("0.05"), Rate: VAT9},
+	})
+	// 0.0045 各行舍入为 0，合计 0.15 × 9% = 0.0135 → 0.01
+	as.Equal("0.01", m.Total.Tax.String())
+	as.Equal("0.16", m.Total.Gross.String())
+
+	as.Empty(Split(nil).Lines)
+	as.True(Split([]VATLine{{Amount: decimal.Zero, Rate: VAT13}}).Total.Tax.IsZero())
+}